@@ -12,6 +12,12 @@ type Config struct {
     CORSOrigins []string
     BoardRows   int
     BoardCols   int
+    // DefaultMap is the map new games use when none is requested; empty means
+    // the default layout for BoardRows x BoardCols
+    DefaultMap  string
+    // TurnLimit is how many rounds a match lasts before it is decided on
+    // command center HP; 0 means no limit
+    TurnLimit   int
 }
 
 // Load reads configuration from environment variables with sensible defaults.
@@ -20,6 +26,8 @@ func Load() Config {
     cors := getenvDefault("CORS_ORIGINS", "*")
     rowsStr := getenvDefault("BOARD_ROWS", "12")
     colsStr := getenvDefault("BOARD_COLS", "12")
+    defaultMap := os.Getenv("MAP_ID")
+    turnLimitStr := getenvDefault("TURN_LIMIT", "30")
 
     rows, err := strconv.Atoi(rowsStr)
     if err != nil {
@@ -29,14 +37,20 @@ func Load() Config {
     if err != nil {
         cols = 12
     }
+    turnLimit, err := strconv.Atoi(turnLimitStr)
+    if err != nil || turnLimit < 0 {
+        turnLimit = 30
+    }
 
     cfg := Config{
         HTTPPort:    port,
         CORSOrigins: []string{cors},
         BoardRows:   rows,
         BoardCols:   cols,
+        DefaultMap:  defaultMap,
+        TurnLimit:   turnLimit,
     }
-    log.Printf("config: port=%s cors=%v board_rows=%d board_cols=%d", cfg.HTTPPort, cfg.CORSOrigins, cfg.BoardRows, cfg.BoardCols)
+    log.Printf("config: port=%s cors=%v board_rows=%d board_cols=%d map=%q turn_limit=%d", cfg.HTTPPort, cfg.CORSOrigins, cfg.BoardRows, cfg.BoardCols, cfg.DefaultMap, cfg.TurnLimit)
     return cfg
 }
 
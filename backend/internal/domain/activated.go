@@ -0,0 +1,289 @@
+package domain
+
+import (
+	"strings"
+)
+
+// ActivatedAbility describes an ability a player may activate for one of
+// their units during Planning. Each unit may activate one ability per round.
+type ActivatedAbility struct {
+	Keyword  KeywordID
+	ManaCost int
+	// Speed is the step the ability resolves in
+	Speed   ActionSpeed
+	resolve func(ctx *abilityContext)
+}
+
+// abilityContext carries an activation being resolved.
+type abilityContext struct {
+	gs     *GameState
+	log    *EventLog
+	step   string
+	action Action
+	unit   *Unit
+	kw     Keyword
+	data   map[string]any
+}
+
+// activatedAbilities are the activated abilities the engine supports, keyed
+// by the keyword a unit must carry to use them.
+var activatedAbilities = map[KeywordID]ActivatedAbility{
+	KeywordHoldPosition: {Keyword: KeywordHoldPosition, Speed: ActionSpeedFast, resolve: holdPosition},
+	KeywordAgile:        {Keyword: KeywordAgile, Speed: ActionSpeedFast, resolve: agileStep},
+	KeywordRally:        {Keyword: KeywordRally, ManaCost: 1, Speed: ActionSpeedNormal, resolve: rally},
+	KeywordOvercharge:   {Keyword: KeywordOvercharge, ManaCost: 1, Speed: ActionSpeedFast, resolve: overcharge},
+	KeywordFortify:      {Keyword: KeywordFortify, ManaCost: 1, Speed: ActionSpeedNormal, resolve: fortify},
+	KeywordSmokeBomb:    {Keyword: KeywordSmokeBomb, ManaCost: 1, Speed: ActionSpeedFast, resolve: smokeBomb},
+	KeywordRepair:       {Keyword: KeywordRepair, ManaCost: 1, Speed: ActionSpeedSlow, resolve: repair},
+}
+
+// Activation rejection reasons reported to clients.
+const (
+	AbilityUnknown        = "unknown_ability"
+	AbilityUnknownUnit    = "unknown_unit"
+	AbilityNotAvailable   = "ability_not_available"
+	AbilityAlreadyUsed    = "ability_already_used"
+	AbilityInvalidTarget  = "invalid_target"
+	AbilityOutOfResources = "out_of_resources"
+)
+
+// LookupActivatedAbility returns the activated ability named by an action's
+// "ability" param, e.g. "rally" or "Smoke Bomb".
+func LookupActivatedAbility(a Action) (ActivatedAbility, bool) {
+	name, _ := a.Params["ability"].(string)
+	id := KeywordID(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_"))
+	ability, ok := activatedAbilities[id]
+	return ability, ok
+}
+
+// ValidateActivations checks a player's submitted actions. Each
+// activate_ability action must name an ability its unit carries, its unit
+// may activate at most once per round, targets must be legal, and the Mana
+// costs must be affordable on top of the player's planned card plays.
+// Accepted activations get the ability's speed. Returns the accepted actions
+// and the rejection reason for each rejected index.
+func (gs *GameState) ValidateActivations(playerIndex int, actions ActionQueue) (ActionQueue, map[int]string) {
+	accepted := make(ActionQueue, 0, len(actions))
+	rejected := map[int]string{}
+	used := map[UnitID]bool{}
+	mana := gs.plannedCardCost(playerIndex, "").Mana
+	have := gs.GetPlayerResources(playerIndex).Mana
+	for i, a := range actions {
+		if a.Type != ActionTypeActivateAbility {
+			accepted = append(accepted, a)
+			continue
+		}
+		ability, ok := LookupActivatedAbility(a)
+		if !ok {
+			rejected[i] = AbilityUnknown
+			continue
+		}
+		u := gs.GetUnit(UnitID(a.SourceID))
+		if u == nil || !u.IsAlive() || u.PlayerIndex != playerIndex {
+			rejected[i] = AbilityUnknownUnit
+			continue
+		}
+		kw, ok := u.Keyword(ability.Keyword)
+		if !ok {
+			rejected[i] = AbilityNotAvailable
+			continue
+		}
+		if used[u.ID] || u.AbilityUsedTurn == gs.CurrentTurn {
+			rejected[i] = AbilityAlreadyUsed
+			continue
+		}
+		if !gs.activationTargetValid(u, ability, kw, a) {
+			rejected[i] = AbilityInvalidTarget
+			continue
+		}
+		if mana+ability.ManaCost > have {
+			rejected[i] = AbilityOutOfResources
+			continue
+		}
+		mana += ability.ManaCost
+		used[u.ID] = true
+		a.Speed = ability.Speed
+		accepted = append(accepted, a)
+	}
+	return accepted, rejected
+}
+
+// activationTargetValid checks the target of abilities that take one: Agile
+// needs a free tile one column to the side, Repair an adjacent allied
+// structure if one is named.
+func (gs *GameState) activationTargetValid(u *Unit, ability ActivatedAbility, kw Keyword, a Action) bool {
+	switch ability.Keyword {
+	case KeywordAgile:
+		return agileTileValid(gs, u, a.Position)
+	case KeywordRepair:
+		if a.TargetID == "" {
+			return true
+		}
+		st := gs.structureByID(StructureID(a.TargetID))
+		return st != nil && gs.AreAllies(st.PlayerIndex, u.PlayerIndex) && st.distanceTo(u.Position) == 1
+	}
+	return true
+}
+
+// pendingAbilityCost is the Mana cost of the player's queued activations.
+func (gs *GameState) pendingAbilityCost(playerIndex int) Resources {
+	var total Resources
+	for _, a := range gs.PendingActions[playerIndex] {
+		if a.Type != ActionTypeActivateAbility {
+			continue
+		}
+		if ability, ok := LookupActivatedAbility(a); ok {
+			total.Mana += ability.ManaCost
+		}
+	}
+	return total
+}
+
+// resolveActivatedAbility pays for and resolves one activation. Activations
+// whose unit is gone, has already used its ability this round, or whose
+// owner can no longer pay fizzle.
+func resolveActivatedAbility(gs *GameState, log *EventLog, step string, a Action) {
+	data := map[string]any{
+		"playerIndex": a.PlayerIndex,
+		"action":      string(a.Type),
+		"unitId":      a.SourceID,
+	}
+	ability, ok := LookupActivatedAbility(a)
+	if !ok {
+		data["fizzled"] = true
+		data["reason"] = AbilityUnknown
+		log.AddSimple(EventTypeEffect, step, data)
+		return
+	}
+	data["ability"] = string(ability.Keyword)
+
+	u := gs.GetUnit(UnitID(a.SourceID))
+	var kw Keyword
+	reason := ""
+	switch {
+	case u == nil || !u.IsAlive() || u.PlayerIndex != a.PlayerIndex:
+		reason = AbilityUnknownUnit
+	case u.AbilityUsedTurn == gs.CurrentTurn:
+		reason = AbilityAlreadyUsed
+	default:
+		var ok bool
+		if kw, ok = u.Keyword(ability.Keyword); !ok {
+			reason = AbilityNotAvailable
+		} else if !gs.SpendResources(a.PlayerIndex, Resources{Mana: ability.ManaCost}) {
+			reason = AbilityOutOfResources
+		}
+	}
+	if reason != "" {
+		data["fizzled"] = true
+		data["reason"] = reason
+		log.AddSimple(EventTypeEffect, step, data)
+		return
+	}
+
+	u.AbilityUsedTurn = gs.CurrentTurn
+	data["manaSpent"] = ability.ManaCost
+	ctx := &abilityContext{gs: gs, log: log, step: step, action: a, unit: u, kw: kw, data: data}
+	ability.resolve(ctx)
+	log.AddSimple(EventTypeEffect, step, data)
+}
+
+// --- Ability behaviours ---
+
+// holdPosition toggles whether the unit advances during movement.
+func holdPosition(ctx *abilityContext) {
+	ctx.unit.Holding = !ctx.unit.Holding
+	ctx.data["holding"] = ctx.unit.Holding
+}
+
+// agileStep moves the unit one column to the side before movement.
+func agileStep(ctx *abilityContext) {
+	u := ctx.unit
+	to := ctx.action.Position
+	if !agileTileValid(ctx.gs, u, to) {
+		ctx.data["fizzled"] = true
+		ctx.data["reason"] = AbilityInvalidTarget
+		return
+	}
+	from := u.Position
+	u.Position = to
+	ctx.log.AddSimple(EventTypeMovement, ctx.step, map[string]any{
+		"unitId":      u.ID,
+		"playerIndex": u.PlayerIndex,
+		"fromRow":     from.Row,
+		"fromCol":     from.Col,
+		"toRow":       to.Row,
+		"toCol":       to.Col,
+		"lateral":     true,
+	})
+	runMoveHooks(ctx.gs, u, from, to, ctx.log)
+	refreshAuras(ctx.gs)
+}
+
+// rally gives adjacent allied units, teammates' included, +X Attack this round.
+func rally(ctx *abilityContext) {
+	var allies []UnitID
+	for _, other := range ctx.gs.Units {
+		if other == ctx.unit || !other.IsAlive() || !ctx.gs.AreAllies(other.PlayerIndex, ctx.unit.PlayerIndex) || !adjacent(other.Position, ctx.unit.Position) {
+			continue
+		}
+		ctx.gs.ApplyUnitStatus(other, StatusAttack, ctx.kw.Value, string(ctx.unit.ID), ctx.gs.CurrentTurn, ctx.step, ctx.log)
+		allies = append(allies, other.ID)
+	}
+	ctx.data["targets"] = allies
+}
+
+// overcharge gives the unit +X Speed this round.
+func overcharge(ctx *abilityContext) {
+	ctx.gs.ApplyUnitStatus(ctx.unit, StatusSpeed, ctx.kw.Value, string(ctx.unit.ID), ctx.gs.CurrentTurn, ctx.step, ctx.log)
+}
+
+// fortify gives the unit +X Armor this round.
+func fortify(ctx *abilityContext) {
+	ctx.gs.ApplyUnitStatus(ctx.unit, StatusArmor, ctx.kw.Value, string(ctx.unit.ID), ctx.gs.CurrentTurn, ctx.step, ctx.log)
+}
+
+// smokeBomb gives the unit Stealth this round.
+func smokeBomb(ctx *abilityContext) {
+	ctx.gs.ApplyUnitStatus(ctx.unit, StatusStealth, 0, string(ctx.unit.ID), ctx.gs.CurrentTurn, ctx.step, ctx.log)
+}
+
+// repair heals an adjacent allied structure by X, a teammate's included: the
+// targeted one, or the most damaged one if none was named.
+func repair(ctx *abilityContext) {
+	u := ctx.unit
+	var target *Structure
+	if id := ctx.action.TargetID; id != "" {
+		target = ctx.gs.structureByID(StructureID(id))
+	} else {
+		for _, st := range ctx.gs.Structures {
+			if ctx.gs.AreAllies(st.PlayerIndex, u.PlayerIndex) && st.distanceTo(u.Position) == 1 && (target == nil || st.MaxHealth-st.Health > target.MaxHealth-target.Health) {
+				target = st
+			}
+		}
+	}
+	if target == nil || !ctx.gs.AreAllies(target.PlayerIndex, u.PlayerIndex) || target.distanceTo(u.Position) != 1 {
+		ctx.data["fizzled"] = true
+		ctx.data["reason"] = AbilityInvalidTarget
+		return
+	}
+	healed := min(ctx.kw.Value, target.MaxHealth-target.Health)
+	target.Health += healed
+	ctx.data["targetType"] = "structure"
+	ctx.data["targetId"] = target.ID
+	ctx.data["amount"] = healed
+	ctx.data["health"] = target.Health
+}
+
+// agileTileValid reports whether to is a free tile one column beside the unit
+// whose terrain it can enter.
+func agileTileValid(gs *GameState, u *Unit, to Point) bool {
+	if to.Row != u.Position.Row || abs(to.Col-u.Position.Col) != 1 {
+		return false
+	}
+	return gs.InBounds(to.Row, to.Col) && !gs.isTileBlocked(to.Row, to.Col) && gs.canEnter(u, to.Row, to.Col)
+}
+
+// adjacent reports whether two tiles share an edge.
+func adjacent(a, b Point) bool {
+	return abs(a.Row-b.Row)+abs(a.Col-b.Col) == 1
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"testing"
+)
+
+func activation(unit *Unit, ability string) Action {
+	return Action{
+		PlayerIndex: unit.PlayerIndex,
+		Type:        ActionTypeActivateAbility,
+		SourceID:    string(unit.ID),
+		Params:      map[string]any{"ability": ability},
+	}
+}
+
+func TestValidateActivationsChecksKeywordOncePerRoundAndMana(t *testing.T) {
+	gs := NewGameState("ability-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0, Resources: Resources{Mana: 1}}, {PlayerIndex: 1}}
+	captain := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 3, Speed: 1, Range: 1})
+	captain.Keywords = []Keyword{{ID: KeywordRally, Value: 2}, {ID: KeywordFortify, Value: 1}}
+	grunt := placeTestUnit(gs, 0, Point{Row: 8, Col: 4}, UnitStats{Attack: 1, Health: 3, Speed: 1, Range: 1})
+	grunt.Keywords = []Keyword{{ID: KeywordOvercharge, Value: 1}}
+	enemy := placeTestUnit(gs, 1, Point{Row: 3, Col: 3}, UnitStats{Health: 3})
+
+	accepted, rejected := gs.ValidateActivations(0, ActionQueue{
+		activation(captain, "Rally"),
+		activation(captain, "fortify"),
+		activation(grunt, "overcharge"),
+		activation(grunt, "smoke_bomb"),
+		activation(enemy, "rally"),
+	})
+	if len(accepted) != 1 || accepted[0].Speed != ActionSpeedNormal {
+		t.Fatalf("Expected only the rally accepted at normal speed, got %+v", accepted)
+	}
+	want := map[int]string{1: AbilityAlreadyUsed, 2: AbilityOutOfResources, 3: AbilityNotAvailable, 4: AbilityUnknownUnit}
+	for i, reason := range want {
+		if rejected[i] != reason {
+			t.Errorf("Expected action %d rejected with %q, got %q", i, reason, rejected[i])
+		}
+	}
+}
+
+func TestRallyResolvesInItsStepAndSpendsMana(t *testing.T) {
+	gs := NewGameState("ability-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0, Resources: Resources{Mana: 2}}, {PlayerIndex: 1}}
+	captain := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 3, Range: 1})
+	captain.Keywords = []Keyword{{ID: KeywordRally, Value: 2}}
+	ally := placeTestUnit(gs, 0, Point{Row: 8, Col: 4}, UnitStats{Attack: 1, Health: 3, Range: 1})
+
+	queue, _ := gs.ValidateActivations(0, ActionQueue{activation(captain, "rally")})
+	log := NewEventLog(gs.CurrentTurn)
+	resolveUniversalStep(gs, log, "fast", filterBySpeed(queue, ActionSpeedFast))
+	if ally.Attack != 1 {
+		t.Fatal("Expected rally not to resolve in the fast step")
+	}
+	resolveUniversalStep(gs, log, "normal", filterBySpeed(queue, ActionSpeedNormal))
+	if ally.Attack != 3 || captain.Attack != 1 {
+		t.Errorf("Expected only the adjacent ally rallied, got ally=%d captain=%d", ally.Attack, captain.Attack)
+	}
+	if gs.PlayerStates[0].Resources.Mana != 1 {
+		t.Errorf("Expected 1 Mana spent, have %d", gs.PlayerStates[0].Resources.Mana)
+	}
+
+	// A second activation in the same round fizzles
+	resolveUniversalStep(gs, log, "normal", filterBySpeed(queue, ActionSpeedNormal))
+	if ally.Attack != 3 || gs.PlayerStates[0].Resources.Mana != 1 {
+		t.Errorf("Expected the repeat activation to fizzle, got ally=%d mana=%d", ally.Attack, gs.PlayerStates[0].Resources.Mana)
+	}
+}
+
+func TestHoldPositionAndAgileAffectMovement(t *testing.T) {
+	gs := NewGameState("ability-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0}, {PlayerIndex: 1}}
+	holder := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Health: 3, Speed: 1})
+	holder.Keywords = []Keyword{{ID: KeywordHoldPosition}}
+	scout := placeTestUnit(gs, 0, Point{Row: 8, Col: 6}, UnitStats{Health: 3, Speed: 1})
+	scout.Keywords = []Keyword{{ID: KeywordAgile}}
+
+	step := activation(scout, "agile")
+	step.Position = Point{Row: 8, Col: 7}
+	queue, rejected := gs.ValidateActivations(0, ActionQueue{activation(holder, "hold position"), step})
+	if len(rejected) != 0 {
+		t.Fatalf("Expected both activations accepted, got %v", rejected)
+	}
+	log := NewEventLog(gs.CurrentTurn)
+	resolveUniversalStep(gs, log, "fast", filterBySpeed(queue, ActionSpeedFast))
+	resolveMovement(gs, log)
+
+	if holder.Position != (Point{Row: 8, Col: 3}) || !holder.Holding {
+		t.Errorf("Expected the holding unit to stay put, got %+v", holder.Position)
+	}
+	if scout.Position != (Point{Row: 7, Col: 7}) {
+		t.Errorf("Expected the agile unit to sidestep then advance, got %+v", scout.Position)
+	}
+}
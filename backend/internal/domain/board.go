@@ -0,0 +1,269 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// UnitID uniquely identifies a unit on the board within a single game.
+type UnitID string
+
+// Unit is a unit instance on the board. It is created when a unit card
+// resolves and keeps a link back to the card instance that summoned it.
+type Unit struct {
+	ID             UnitID         `json:"id"`
+	PlayerIndex    int            `json:"playerIndex"`
+	CardID         CardID         `json:"cardId"`
+	CardInstanceID CardInstanceID `json:"cardInstanceId"`
+	Position       Point          `json:"position"`
+	// Base stats come from the card; Modifiers is the ordered stack applied on
+	// top of them
+	Base      UnitStats      `json:"base"`
+	Modifiers []StatModifier `json:"modifiers,omitempty"`
+	// Effective stats, recomputed from Base and Modifiers. Health is current HP.
+	Attack    int `json:"attack"`
+	Health    int `json:"health"`
+	MaxHealth int `json:"maxHealth"`
+	Armor     int `json:"armor"`
+	Speed     int `json:"speed"`
+	Range     int `json:"range"`
+	// Keywords are the unit's parsed abilities
+	Keywords []Keyword `json:"keywords"`
+	// Holding units stay put during movement (toggled by Hold Position)
+	Holding bool `json:"holding,omitempty"`
+	// AbilityUsedTurn is the last turn the unit activated an ability, or -1
+	AbilityUsedTurn int `json:"abilityUsedTurn"`
+	// IsHero marks the player's hero, which returns to the command zone on death
+	IsHero bool `json:"isHero,omitempty"`
+	// SummonedTurn is the turn on which the unit entered the board
+	SummonedTurn int `json:"summonedTurn"`
+}
+
+// IsAlive returns true if the unit has health remaining.
+func (u *Unit) IsAlive() bool {
+	return u.Health > 0
+}
+
+// CardInstance returns the card instance that summoned this unit.
+func (u *Unit) CardInstance() CardInstance {
+	return CardInstance{InstanceID: u.CardInstanceID, CardID: u.CardID}
+}
+
+// SetCardCatalog registers the card definitions the engine resolves plays against.
+func (gs *GameState) SetCardCatalog(cards []*Card) {
+	gs.Cards = make(map[CardID]*Card, len(cards))
+	for _, c := range cards {
+		if c != nil {
+			gs.Cards[c.ID] = c
+		}
+	}
+}
+
+// LookupCard returns the card definition for the given ID, or nil if unknown.
+func (gs *GameState) LookupCard(id CardID) *Card {
+	if gs.Cards == nil {
+		return nil
+	}
+	return gs.Cards[id]
+}
+
+// InBounds returns true if the tile lies on the board.
+func (gs *GameState) InBounds(row, col int) bool {
+	return row >= 0 && col >= 0 && row < gs.BoardRows && col < gs.BoardCols
+}
+
+// UnitAt returns the unit standing on the given tile, or nil.
+func (gs *GameState) UnitAt(row, col int) *Unit {
+	for _, u := range gs.Units {
+		if u.Position.Row == row && u.Position.Col == col {
+			return u
+		}
+	}
+	return nil
+}
+
+// GetUnit returns the unit with the given ID, or nil.
+func (gs *GameState) GetUnit(id UnitID) *Unit {
+	for _, u := range gs.Units {
+		if u.ID == id {
+			return u
+		}
+	}
+	return nil
+}
+
+// PlayerUnits returns the units owned by a player in board order.
+func (gs *GameState) PlayerUnits(playerIndex int) []*Unit {
+	var out []*Unit
+	for _, u := range gs.Units {
+		if u.PlayerIndex == playerIndex {
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+// SpawnUnit places a new unit for the given card instance on the board.
+// Returns nil if the card has no unit or hero stats, its abilities do not
+// parse, or the tile cannot hold a unit, including impassable terrain for a
+// unit without Flying.
+func (gs *GameState) SpawnUnit(playerIndex int, instance CardInstance, card *Card, pos Point) *Unit {
+	if card == nil || card.BoardStats() == nil {
+		return nil
+	}
+	if !gs.InBounds(pos.Row, pos.Col) || gs.isTileBlocked(pos.Row, pos.Col) {
+		return nil
+	}
+	keywords, err := ParseAbilities(card.Abilities)
+	if err != nil {
+		// Catalogs validate abilities on load, so this is bad card data
+		return nil
+	}
+	if gs.TerrainRulesAt(pos.Row, pos.Col).Impassable && !cardHasKeyword(card, KeywordFlying) {
+		return nil
+	}
+	gs.unitSeq++
+	stats := card.BoardStats()
+	u := &Unit{
+		ID:              UnitID(fmt.Sprintf("unit-%d", gs.unitSeq)),
+		PlayerIndex:     playerIndex,
+		CardID:          card.ID,
+		CardInstanceID:  instance.InstanceID,
+		Position:        pos,
+		Base:            *stats,
+		Health:          stats.Health,
+		MaxHealth:       stats.Health,
+		Keywords:        keywords,
+		IsHero:          card.IsHero(),
+		SummonedTurn:    gs.CurrentTurn,
+		AbilityUsedTurn: -1,
+	}
+	u.recomputeStats()
+	gs.Units = append(gs.Units, u)
+	gs.UpdatedAt = time.Now()
+	return u
+}
+
+// RemoveUnit takes a unit off the board. Returns the removed unit, or nil.
+func (gs *GameState) RemoveUnit(id UnitID) *Unit {
+	for i, u := range gs.Units {
+		if u.ID == id {
+			gs.Units = append(gs.Units[:i], gs.Units[i+1:]...)
+			gs.UpdatedAt = time.Now()
+			return u
+		}
+	}
+	return nil
+}
+
+// isTileBlocked reports whether a structure or unit currently stands on the tile.
+// Unlike IsTileOccupied it ignores staged plays, which have already been revealed
+// by the time anything is placed during resolution.
+func (gs *GameState) isTileBlocked(row, col int) bool {
+	if gs.structureAt(row, col) {
+		return true
+	}
+	return gs.UnitAt(row, col) != nil
+}
+
+// structureAt reports whether a command center footprint or another structure
+// covers the tile.
+func (gs *GameState) structureAt(row, col int) bool {
+	for _, cc := range gs.CommandCenters {
+		if cc.Covers(row, col) {
+			return true
+		}
+	}
+	return gs.StructureAt(row, col) != nil
+}
+
+// resolveSummons puts revealed unit and hero cards onto the board and builds
+// revealed building cards. A summon whose tile has become blocked, or whose card
+// has abilities the engine rejects, fizzles; its card goes to the discard pile,
+// or back to the command zone for a hero.
+func resolveSummons(gs *GameState, log *EventLog, summons []PlannedPlay) {
+	for _, p := range summons {
+		if p.PlayerIndex < 0 || p.PlayerIndex >= len(gs.PlayerStates) {
+			continue
+		}
+		instance := CardInstance{InstanceID: p.CardInstance, CardID: p.CardID}
+		if card := gs.LookupCard(p.CardID); card != nil && card.IsBuilding() {
+			resolveBuild(gs, log, p, instance, card)
+			continue
+		}
+		card := gs.LookupCard(p.CardID)
+		u := gs.SpawnUnit(p.PlayerIndex, instance, card, p.Position)
+		if u == nil {
+			ps := &gs.PlayerStates[p.PlayerIndex]
+			if card != nil && card.IsHero() {
+				ps.CommandZone = append(ps.CommandZone, instance)
+			} else {
+				ps.DiscardPile = append(ps.DiscardPile, instance)
+			}
+			data := map[string]any{
+				"playerIndex":    p.PlayerIndex,
+				"cardId":         p.CardID,
+				"cardInstanceId": p.CardInstance,
+				"row":            p.Position.Row,
+				"col":            p.Position.Col,
+				"blocked":        true,
+			}
+			if card != nil {
+				if _, err := ParseAbilities(card.Abilities); err != nil {
+					data["blocked"] = false
+					data["error"] = fmt.Sprintf("card %s: %v", card.ID, err)
+				}
+			}
+			log.AddSimple(EventTypeSummon, "summon", data)
+			continue
+		}
+		runSummonHooks(gs, u, log)
+		log.AddSimple(EventTypeSummon, "summon", map[string]any{
+			"playerIndex":    u.PlayerIndex,
+			"unitId":         u.ID,
+			"cardId":         u.CardID,
+			"cardInstanceId": u.CardInstanceID,
+			"row":            u.Position.Row,
+			"col":            u.Position.Col,
+			"attack":         u.Attack,
+			"health":         u.Health,
+			"armor":          u.Armor,
+			"speed":          u.Speed,
+			"range":          u.Range,
+		})
+	}
+	refreshAuras(gs)
+}
+
+// resolveBuild places the structure for a revealed building card. The card
+// stays with the structure until it is destroyed; a blocked build fizzles and
+// the card goes to the discard pile.
+func resolveBuild(gs *GameState, log *EventLog, p PlannedPlay, instance CardInstance, card *Card) {
+	st := gs.BuildStructure(p.PlayerIndex, instance, card, p.Position)
+	if st == nil {
+		ps := &gs.PlayerStates[p.PlayerIndex]
+		ps.DiscardPile = append(ps.DiscardPile, instance)
+		log.AddSimple(EventTypeSummon, "summon", map[string]any{
+			"playerIndex":    p.PlayerIndex,
+			"cardId":         p.CardID,
+			"cardInstanceId": p.CardInstance,
+			"row":            p.Position.Row,
+			"col":            p.Position.Col,
+			"blocked":        true,
+		})
+		return
+	}
+	log.AddSimple(EventTypeSummon, "summon", map[string]any{
+		"playerIndex":    st.PlayerIndex,
+		"structureId":    st.ID,
+		"cardId":         st.CardID,
+		"cardInstanceId": st.CardInstanceID,
+		"row":            st.Position.Row,
+		"col":            st.Position.Col,
+		"health":         st.Health,
+		"armor":          st.Armor,
+		"attack":         st.Attack,
+		"range":          st.Range,
+		"income":         st.Building.ResourceGen,
+	})
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"testing"
+)
+
+func newBoardTestState() *GameState {
+	gs := NewGameState("board-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.SetCardCatalog([]*Card{
+		{
+			ID:        "goblin",
+			Type:      CardTypeUnit,
+			UnitStats: &UnitStats{Attack: 2, Health: 2, Armor: 0, Speed: 1, Range: 1},
+		},
+		{
+			ID:          "bolt",
+			Type:        CardTypeSpell,
+			SpellEffect: &SpellEffect{TargetType: "unit", Effect: "Deal 2 damage"},
+		},
+	})
+	gs.PlayerStates = []PlayerBattleState{
+		{
+			PlayerIndex: 0,
+			Hand: []CardInstance{
+				{InstanceID: "g-1", CardID: "goblin"},
+				{InstanceID: "b-1", CardID: "bolt"},
+			},
+		},
+		{PlayerIndex: 1},
+	}
+	return gs
+}
+
+func TestSummonUnitOnResolution(t *testing.T) {
+	gs := newBoardTestState()
+	gs.AddPlannedPlay(PlannedPlay{PlayerIndex: 0, CardInstance: "g-1", CardID: "goblin", Position: Point{Row: 8, Col: 3}})
+	gs.AddPlannedPlay(PlannedPlay{PlayerIndex: 0, CardInstance: "b-1", CardID: "bolt", Position: Point{Row: 4, Col: 3}})
+
+	log := ExecuteResolutionPhase(gs, ActionQueue{}, ActionQueue{})
+
+	if len(gs.Units) != 1 {
+		t.Fatalf("Expected 1 unit on the board, got %d", len(gs.Units))
+	}
+	u := gs.Units[0]
+	if u.PlayerIndex != 0 || u.CardInstanceID != "g-1" || u.CardID != "goblin" {
+		t.Errorf("Unexpected unit ownership/link: %+v", u)
+	}
+	if u.Attack != 2 || u.Health != 2 || u.MaxHealth != 2 || u.Speed != 1 || u.Range != 1 {
+		t.Errorf("Unit stats not copied from card: %+v", u)
+	}
+
+	p0 := &gs.PlayerStates[0]
+	if len(p0.Hand) != 0 {
+		t.Errorf("Expected empty hand, got %d cards", len(p0.Hand))
+	}
+	// The spell is discarded; the unit card stays with the unit on the board
+	if len(p0.DiscardPile) != 1 || p0.DiscardPile[0].InstanceID != "b-1" {
+		t.Errorf("Expected only the spell in the discard pile, got %v", p0.DiscardPile)
+	}
+
+	summonEvents := 0
+	for _, evt := range log.Events {
+		if evt.Type == EventTypeSummon {
+			summonEvents++
+			if evt.Data["unitId"] != u.ID {
+				t.Errorf("Summon event should reference unit %s, got %v", u.ID, evt.Data["unitId"])
+			}
+		}
+	}
+	if summonEvents != 1 {
+		t.Errorf("Expected 1 summon event, got %d", summonEvents)
+	}
+}
+
+func TestSpawnUnitRejectsOccupiedTile(t *testing.T) {
+	gs := newBoardTestState()
+	card := gs.LookupCard("goblin")
+
+	if u := gs.SpawnUnit(0, CardInstance{InstanceID: "a", CardID: "goblin"}, card, Point{Row: 5, Col: 5}); u == nil {
+		t.Fatal("Expected first spawn to succeed")
+	}
+	if u := gs.SpawnUnit(1, CardInstance{InstanceID: "b", CardID: "goblin"}, card, Point{Row: 5, Col: 5}); u != nil {
+		t.Error("Expected spawn on a unit-occupied tile to fail")
+	}
+	cc := gs.GetCommandCenter(0)
+	if u := gs.SpawnUnit(0, CardInstance{InstanceID: "c", CardID: "goblin"}, card, Point{Row: cc.TopLeftRow, Col: cc.TopLeftCol}); u != nil {
+		t.Error("Expected spawn on a command center tile to fail")
+	}
+	if !gs.IsTileOccupied(5, 5) {
+		t.Error("Tile with a unit should be reported as occupied")
+	}
+}
+
+func TestSummonWithInvalidAbilitiesFizzles(t *testing.T) {
+	gs := newBoardTestState()
+	gs.SetCardCatalog([]*Card{{ID: "typo", Type: CardTypeUnit, UnitStats: &UnitStats{Health: 2}, Abilities: []string{"Hsate"}}})
+	gs.PlayerStates[0].Hand = []CardInstance{{InstanceID: "t-1", CardID: "typo"}}
+	gs.AddPlannedPlay(PlannedPlay{PlayerIndex: 0, CardInstance: "t-1", CardID: "typo", Position: Point{Row: 8, Col: 3}})
+
+	log := ExecuteResolutionPhase(gs, ActionQueue{}, ActionQueue{})
+
+	if len(gs.Units) != 0 {
+		t.Fatalf("Expected a card with bad abilities not to spawn, got %+v", gs.Units[0])
+	}
+	if p0 := gs.PlayerStates[0]; len(p0.DiscardPile) != 1 || p0.DiscardPile[0].InstanceID != "t-1" {
+		t.Errorf("Expected the card in the discard pile, got %v", p0.DiscardPile)
+	}
+	found := false
+	for _, evt := range log.Events {
+		if evt.Type == EventTypeSummon && evt.Data["error"] != nil {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected the summon event to carry the ability error")
+	}
+}
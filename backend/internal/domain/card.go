@@ -44,12 +44,20 @@ type Card struct {
 	UnitStats   *UnitStats `json:"unitStats,omitempty"`
 	// Spell properties (for Spell cards)
 	SpellEffect *SpellEffect `json:"spellEffect,omitempty"`
+	// Order properties (for Order cards)
+	OrderEffect *OrderEffect `json:"orderEffect,omitempty"`
 	// Building properties (for Building cards)
 	BuildingStats *BuildingStats `json:"buildingStats,omitempty"`
 	// Hero properties (for Hero cards)
 	HeroStats   *HeroStats `json:"heroStats,omitempty"`
+	// Aura granted to nearby friendly units while this card's unit is on the board
+	Aura        *AuraEffect `json:"aura,omitempty"`
+	// Effect run from the unit's tile when it dies, for Deathrattle
+	DeathEffect *SpellEffect `json:"deathEffect,omitempty"`
 	// General properties
 	Abilities   []string  `json:"abilities"`
+	// Priority orders same-speed effects; higher resolves first
+	Priority    int       `json:"priority,omitempty"`
 	FlavorText  *string   `json:"flavorText,omitempty"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
@@ -64,10 +72,45 @@ type UnitStats struct {
 	Range  int `json:"range"`
 }
 
-// SpellEffect represents the effect of a spell card.
+// AuraEffect is a stat bonus a unit grants to friendly units within Range
+// tiles (Manhattan distance) while it is on the board. If Tribe is set, only
+// units whose card name contains it are affected.
+type AuraEffect struct {
+	Range  int    `json:"range"`
+	Tribe  string `json:"tribe,omitempty"`
+	Attack int    `json:"attack,omitempty"`
+	Health int    `json:"health,omitempty"`
+	Armor  int    `json:"armor,omitempty"`
+	Speed  int    `json:"speed,omitempty"`
+}
+
+// SpellEffect represents the effect of a spell card. Effect is the rules text
+// shown to players; Operations is what the engine executes.
 type SpellEffect struct {
-	TargetType string `json:"targetType"` // ground, unit, building, hero, global
-	Effect     string `json:"effect"`
+	TargetType string     `json:"targetType"` // ground, unit, building, hero, global, lane
+	Effect     string     `json:"effect"`
+	Operations []EffectOp `json:"operations,omitempty"`
+}
+
+// OrderTarget says what an order card is aimed at. Orders only ever target
+// the caster's own team.
+type OrderTarget string
+
+const (
+	// OrderTargetFriendlyUnit orders target one of the caster's or a
+	// teammate's units.
+	OrderTargetFriendlyUnit OrderTarget = "friendly_unit"
+	// OrderTargetFriendlyLane orders target a column and affect the caster's
+	// and teammates' units in it.
+	OrderTargetFriendlyLane OrderTarget = "friendly_lane"
+)
+
+// OrderEffect represents the effect of an order card. Orders resolve in the
+// spell window and need no free tile.
+type OrderEffect struct {
+	TargetType OrderTarget `json:"targetType"`
+	Effect     string      `json:"effect"`
+	Operations []EffectOp  `json:"operations,omitempty"`
 }
 
 // BuildingStats represents the stats of a building created by a Building card.
@@ -76,6 +119,8 @@ type BuildingStats struct {
 	Armor  int  `json:"armor"`
 	Attack *int `json:"attack,omitempty"` // Optional for defensive buildings
 	Range  *int `json:"range,omitempty"`  // Optional for defensive buildings
+	// Income is generated for the owner each turn while the structure stands
+	Income *ResourceGeneration `json:"income,omitempty"`
 }
 
 // HeroStats represents the stats of a hero.
@@ -86,6 +131,26 @@ type HeroStats struct {
 	Speed    int `json:"speed"`
 	Range    int `json:"range"`
 	Cooldown int `json:"cooldown"` // Turns before hero can respawn
+	// Passives are deck modifiers applied when the match starts
+	Passives []HeroPassive `json:"passives,omitempty"`
+}
+
+// HeroPassiveType names a passive modifier a hero applies to its player.
+type HeroPassiveType string
+
+const (
+	// HeroPassiveStartingGold adds Value Gold to the player's starting bank.
+	HeroPassiveStartingGold HeroPassiveType = "starting_gold"
+	// HeroPassiveHandLimit raises the player's hand limit by Value.
+	HeroPassiveHandLimit HeroPassiveType = "hand_limit"
+	// HeroPassiveTowerMana makes each of the player's Towers generate Value extra Mana.
+	HeroPassiveTowerMana HeroPassiveType = "tower_mana"
+)
+
+// HeroPassive is a single passive modifier with its amount.
+type HeroPassive struct {
+	Type  HeroPassiveType `json:"type"`
+	Value int             `json:"value"`
 }
 
 // IsUnit returns true if the card is a unit card.
@@ -113,6 +178,11 @@ func (c *Card) IsHero() bool {
 	return c.Type == CardTypeHero
 }
 
+// Cost returns the card's gold and mana cost.
+func (c *Card) Cost() Resources {
+	return Resources{Gold: c.GoldCost, Mana: c.ManaCost}
+}
+
 // TotalCost calculates the total resource cost of a card.
 func (c *Card) TotalCost() int {
 	return c.GoldCost + c.ManaCost
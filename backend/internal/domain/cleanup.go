@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	// DefaultHandLimit is the hand size players draw up to each upkeep.
+	DefaultHandLimit = 7
+	// HandHardCap is the most cards a hand may ever hold. Draws past it are
+	// skipped and the cards stay in the draw pile.
+	HandHardCap = 10
+)
+
+// Cleanup discard rejection reasons reported to clients.
+const (
+	CleanupDiscardNotRequired = "discard_not_required"
+	CleanupDiscardWrongCount  = "wrong_discard_count"
+	CleanupDiscardNotInHand   = "card_not_in_hand"
+)
+
+// cleanupHandLimit is the hand size a player discards down to during Cleanup.
+func (ps *PlayerBattleState) cleanupHandLimit() int {
+	limit := ps.HandLimit
+	if limit <= 0 {
+		limit = DefaultHandLimit
+	}
+	return min(limit, HandHardCap)
+}
+
+// BeginCleanup works out how many cards each player must discard to get back
+// to their hand limit. Returns true if any player has a discard to choose.
+func (gs *GameState) BeginCleanup() bool {
+	pending := false
+	for i := range gs.PlayerStates {
+		ps := &gs.PlayerStates[i]
+		ps.DiscardRequired = max(0, len(ps.Hand)-ps.cleanupHandLimit())
+		ps.CleanupDiscards = nil
+		if ps.DiscardRequired > 0 {
+			pending = true
+		}
+	}
+	gs.UpdatedAt = time.Now()
+	return pending
+}
+
+// ChooseCleanupDiscards records the cards a player picked to discard down to
+// their hand limit. Returns the rejection reason, or "" if the choice is
+// accepted.
+func (gs *GameState) ChooseCleanupDiscards(playerIndex int, ids []CardInstanceID) string {
+	if playerIndex < 0 || playerIndex >= len(gs.PlayerStates) {
+		return CleanupDiscardNotRequired
+	}
+	ps := &gs.PlayerStates[playerIndex]
+	if ps.DiscardRequired <= 0 || ps.CleanupDiscards != nil {
+		return CleanupDiscardNotRequired
+	}
+	if len(ids) != ps.DiscardRequired {
+		return CleanupDiscardWrongCount
+	}
+	seen := make(map[CardInstanceID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return CleanupDiscardWrongCount
+		}
+		seen[id] = true
+		if !handContains(ps, id) {
+			return CleanupDiscardNotInHand
+		}
+	}
+	ps.CleanupDiscards = append([]CardInstanceID{}, ids...)
+	gs.UpdatedAt = time.Now()
+	return ""
+}
+
+// AutoPickCleanupDiscards chooses a player's cleanup discards for them: the
+// most recently drawn cards go first. It is the fallback when a player does
+// not choose in time.
+func (gs *GameState) AutoPickCleanupDiscards(playerIndex int) {
+	if playerIndex < 0 || playerIndex >= len(gs.PlayerStates) {
+		return
+	}
+	ps := &gs.PlayerStates[playerIndex]
+	if ps.DiscardRequired <= 0 || ps.CleanupDiscards != nil {
+		return
+	}
+	var ids []CardInstanceID
+	for i := len(ps.Hand) - 1; i >= 0 && len(ids) < ps.DiscardRequired; i-- {
+		ids = append(ids, ps.Hand[i].InstanceID)
+	}
+	ps.CleanupDiscards = ids
+}
+
+// CleanupChoicesComplete returns true once every player who has to discard
+// has chosen their cards.
+func (gs *GameState) CleanupChoicesComplete() bool {
+	for _, ps := range gs.PlayerStates {
+		if ps.DiscardRequired > 0 && ps.CleanupDiscards == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ExecuteCleanupPhase ends the round:
+// 1) Discard down to the hand limit, auto-picking for players who did not choose
+// 2) Expire statuses whose duration has run out
+// 3) Emit a round-end summary
+func ExecuteCleanupPhase(gs *GameState) *EventLog {
+	if gs == nil {
+		return NewEventLog(0)
+	}
+	log := NewEventLog(gs.CurrentTurn)
+
+	// 1) Discard to hand limit
+	for i := range gs.PlayerStates {
+		ps := &gs.PlayerStates[i]
+		if ps.DiscardRequired <= 0 {
+			continue
+		}
+		autoPicked := ps.CleanupDiscards == nil
+		gs.AutoPickCleanupDiscards(i)
+		handBefore := len(ps.Hand)
+		gs.DiscardCards(i, ps.CleanupDiscards)
+		log.AddSimple(EventTypeDiscard, "cleanup", map[string]any{
+			"playerIndex": ps.PlayerIndex,
+			"count":       handBefore - len(ps.Hand),
+			"autoPicked":  autoPicked,
+			"handLimit":   ps.cleanupHandLimit(),
+			"handAfter":   len(ps.Hand),
+			"discardPile": len(ps.DiscardPile),
+		})
+		ps.DiscardRequired = 0
+		ps.CleanupDiscards = nil
+	}
+
+	// 2) Expire statuses
+	expireStatuses(gs, log)
+
+	// 3) Round summary
+	players := make([]map[string]any, 0, len(gs.PlayerStates))
+	for _, ps := range gs.PlayerStates {
+		summary := map[string]any{
+			"playerIndex": ps.PlayerIndex,
+			"handCount":   len(ps.Hand),
+			"deckCount":   len(ps.DrawPile),
+			"discardPile": len(ps.DiscardPile),
+			"gold":        ps.Resources.Gold,
+			"mana":        ps.Resources.Mana,
+			"units":       len(gs.PlayerUnits(ps.PlayerIndex)),
+			"structures":  len(gs.PlayerStructures(ps.PlayerIndex)),
+		}
+		if cc := gs.GetCommandCenter(ps.PlayerIndex); cc != nil {
+			summary["commandCenterHealth"] = cc.Health
+		}
+		players = append(players, summary)
+	}
+	log.AddSimple(EventTypeRoundEnd, "cleanup", map[string]any{
+		"turn":    gs.CurrentTurn,
+		"players": players,
+	})
+
+	gs.UpdatedAt = time.Now()
+	return log
+}
+
+func handContains(ps *PlayerBattleState, id CardInstanceID) bool {
+	for _, c := range ps.Hand {
+		if c.InstanceID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+func handOf(n int) []CardInstance {
+	hand := make([]CardInstance, n)
+	for i := range hand {
+		hand[i] = CardInstance{InstanceID: CardInstanceID(fmt.Sprintf("c%d", i)), CardID: "pawn"}
+	}
+	return hand
+}
+
+func TestCleanupDiscardsToHandLimit(t *testing.T) {
+	gs := NewGameState("cleanup-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.PlayerStates = []PlayerBattleState{
+		{PlayerIndex: 0, Hand: handOf(9), HandLimit: 7},
+		{PlayerIndex: 1, Hand: handOf(8), HandLimit: 7},
+	}
+
+	if !gs.BeginCleanup() {
+		t.Fatal("Expected players over their hand limit to have discards pending")
+	}
+	if reason := gs.ChooseCleanupDiscards(0, []CardInstanceID{"c0"}); reason != CleanupDiscardWrongCount {
+		t.Errorf("Expected %q for too few cards, got %q", CleanupDiscardWrongCount, reason)
+	}
+	if reason := gs.ChooseCleanupDiscards(0, []CardInstanceID{"c0", "missing"}); reason != CleanupDiscardNotInHand {
+		t.Errorf("Expected %q for a card not in hand, got %q", CleanupDiscardNotInHand, reason)
+	}
+	if reason := gs.ChooseCleanupDiscards(0, []CardInstanceID{"c0", "c1"}); reason != "" {
+		t.Fatalf("Expected the choice to be accepted, got %q", reason)
+	}
+	if gs.CleanupChoicesComplete() {
+		t.Error("Expected player 1 to still be choosing")
+	}
+
+	ExecuteCleanupPhase(gs)
+
+	p0, p1 := gs.PlayerStates[0], gs.PlayerStates[1]
+	if len(p0.Hand) != 7 || len(p1.Hand) != 7 {
+		t.Fatalf("Expected both hands at 7, got %d and %d", len(p0.Hand), len(p1.Hand))
+	}
+	if handContains(&p0, "c0") || handContains(&p0, "c1") {
+		t.Error("Expected player 0's chosen cards to be discarded")
+	}
+	if handContains(&p1, "c7") || !handContains(&p1, "c0") {
+		t.Error("Expected player 1's most recently drawn card to be auto-picked")
+	}
+	if p0.DiscardRequired != 0 || p1.DiscardRequired != 0 {
+		t.Error("Expected discard requirements to be cleared")
+	}
+}
+
+func TestDrawStopsAtHandHardCap(t *testing.T) {
+	gs := NewGameState("cleanup-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0, Hand: handOf(9), DrawPile: handOf(5)}}
+
+	drawn := drawCardsDeterministic(gs, &gs.PlayerStates[0], 3, NewEventLog(0))
+	if drawn != 1 || len(gs.PlayerStates[0].Hand) != HandHardCap {
+		t.Errorf("Expected 1 card drawn up to the hard cap, drew %d with hand %d", drawn, len(gs.PlayerStates[0].Hand))
+	}
+}
@@ -0,0 +1,211 @@
+package domain
+
+// CombatHit is a single attack chosen during the combat step. Targets are
+// picked for every attacker before any damage is applied, and keyword hooks
+// may adjust the hit in between. The attacker is either a unit or a shooting
+// structure such as a Tower; the target is a unit, a structure or a command
+// center.
+type CombatHit struct {
+	Attacker      *Unit
+	Source        *Structure
+	Target        *Unit
+	Structure     *Structure
+	CommandCenter *CommandCenter
+	// Damage is the raw damage before the target's Armor is applied
+	Damage int
+	// Pierce is how much of the target's Armor the hit ignores
+	Pierce int
+	// Prevented hits deal no damage at all
+	Prevented bool
+}
+
+// Amount returns the damage the hit deals after Armor and Pierce.
+func (h *CombatHit) Amount() int {
+	if h.Prevented {
+		return 0
+	}
+	armor := 0
+	switch {
+	case h.Target != nil:
+		armor = h.Target.Armor
+	case h.Structure != nil:
+		armor = h.Structure.Armor
+	}
+	armor = max(0, armor-h.Pierce)
+	return max(0, h.Damage-armor)
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// distanceToCommandCenter is the Manhattan distance from a tile to the closest
+// tile of a command center's footprint.
+func distanceToCommandCenter(p Point, cc *CommandCenter) int {
+	return cc.Footprint.distance(cc.TopLeft(), p)
+}
+
+// selectCombatTarget applies the default targeting rules: the closest enemy
+// unit in range along the line the attacker moves on, its column unless it is
+// heading sideways, otherwise the closest enemy structure or command center
+// within range. Units of allied players are never targeted. Ties prefer the
+// unit ahead of the attacker, and a command center over another structure.
+func selectCombatTarget(gs *GameState, attacker *Unit) *CombatHit {
+	if attacker.Attack <= 0 || attacker.Range <= 0 {
+		return nil
+	}
+	dir := gs.heading(attacker, attacker.Position)
+	var target *Unit
+	bestDist := 0
+	for _, u := range gs.Units {
+		if gs.AreAllies(u.PlayerIndex, attacker.PlayerIndex) || !u.IsAlive() {
+			continue
+		}
+		dRow, dCol := u.Position.Row-attacker.Position.Row, u.Position.Col-attacker.Position.Col
+		if (dir.Col == 0 && dCol != 0) || (dir.Col != 0 && dRow != 0) {
+			continue
+		}
+		d := abs(dRow) + abs(dCol)
+		if d == 0 || d > attacker.Range {
+			continue
+		}
+		ahead := dRow*dir.Row+dCol*dir.Col > 0
+		if target == nil || d < bestDist || (d == bestDist && ahead) {
+			target = u
+			bestDist = d
+		}
+	}
+	if target != nil {
+		return &CombatHit{Attacker: attacker, Target: target, Damage: attacker.Attack}
+	}
+
+	var cc *CommandCenter
+	for _, candidate := range gs.CommandCenters {
+		if gs.AreAllies(candidate.PlayerIndex, attacker.PlayerIndex) || candidate.IsDestroyed() {
+			continue
+		}
+		d := distanceToCommandCenter(attacker.Position, candidate)
+		if d > attacker.Range {
+			continue
+		}
+		if cc == nil || d < bestDist {
+			cc = candidate
+			bestDist = d
+		}
+	}
+	var st *Structure
+	for _, candidate := range gs.Structures {
+		if gs.AreAllies(candidate.PlayerIndex, attacker.PlayerIndex) || candidate.IsDestroyed() {
+			continue
+		}
+		d := candidate.distanceTo(attacker.Position)
+		if d > attacker.Range {
+			continue
+		}
+		if (cc == nil && st == nil) || d < bestDist {
+			st = candidate
+			bestDist = d
+		}
+	}
+	if st != nil {
+		return &CombatHit{Attacker: attacker, Structure: st, Damage: attacker.Attack}
+	}
+	if cc != nil {
+		return &CombatHit{Attacker: attacker, CommandCenter: cc, Damage: attacker.Attack}
+	}
+	return nil
+}
+
+// selectStructureTarget picks the closest enemy unit within a shooting
+// structure's range, in any direction. Ties go to the unit that entered the
+// board first.
+func selectStructureTarget(gs *GameState, st *Structure) *CombatHit {
+	if st.Attack <= 0 || st.Range <= 0 || st.IsDestroyed() {
+		return nil
+	}
+	var target *Unit
+	bestDist := 0
+	for _, u := range gs.Units {
+		if gs.AreAllies(u.PlayerIndex, st.PlayerIndex) || !u.IsAlive() {
+			continue
+		}
+		d := st.distanceTo(u.Position)
+		if d > st.Range {
+			continue
+		}
+		if target == nil || d < bestDist {
+			target = u
+			bestDist = d
+		}
+	}
+	if target == nil {
+		return nil
+	}
+	return &CombatHit{Source: st, Target: target, Damage: st.Attack}
+}
+
+// resolveCombat resolves the automatic combat step. Every living unit and
+// shooting structure picks a target first, then all damage is applied at once
+// so mutual kills happen.
+func resolveCombat(gs *GameState, log *EventLog) {
+	var hits []*CombatHit
+	for _, u := range gs.Units {
+		if !u.IsAlive() {
+			continue
+		}
+		if hit := selectCombatTarget(gs, u); hit != nil {
+			hits = append(hits, hit)
+		}
+	}
+	for _, st := range gs.Structures {
+		if hit := selectStructureTarget(gs, st); hit != nil {
+			hits = append(hits, hit)
+		}
+	}
+	for _, hit := range hits {
+		runAttackHooks(gs, hit, log)
+	}
+
+	for _, hit := range hits {
+		amount := hit.Amount()
+		data := map[string]any{"amount": amount}
+		if hit.Attacker != nil {
+			data["attackerType"] = "unit"
+			data["attackerId"] = hit.Attacker.ID
+			data["attackerPlayerIndex"] = hit.Attacker.PlayerIndex
+		} else {
+			data["attackerType"] = "structure"
+			data["attackerId"] = hit.Source.ID
+			data["attackerPlayerIndex"] = hit.Source.PlayerIndex
+		}
+		if hit.Prevented {
+			data["prevented"] = true
+		}
+		switch {
+		case hit.Target != nil:
+			hit.Target.Health = max(0, hit.Target.Health-amount)
+			data["targetType"] = "unit"
+			data["targetId"] = hit.Target.ID
+			data["targetPlayerIndex"] = hit.Target.PlayerIndex
+			data["remainingHp"] = hit.Target.Health
+		case hit.Structure != nil:
+			destroyed := gs.DealDamageToStructure(hit.Structure, amount)
+			data["targetType"] = "structure"
+			data["targetId"] = hit.Structure.ID
+			data["targetPlayerIndex"] = hit.Structure.PlayerIndex
+			data["remainingHp"] = hit.Structure.Health
+			data["destroyed"] = destroyed
+		default:
+			destroyed := gs.DealDamageToCommandCenter(hit.CommandCenter.PlayerIndex, amount)
+			data["targetType"] = "command_center"
+			data["targetPlayerIndex"] = hit.CommandCenter.PlayerIndex
+			data["remainingHp"] = hit.CommandCenter.Health
+			data["destroyed"] = destroyed
+		}
+		log.AddSimple(EventTypeDamage, "combat", data)
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestCombatMutualKill(t *testing.T) {
+	gs := NewGameState("combat-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0}, {PlayerIndex: 1}}
+	a := placeTestUnit(gs, 0, Point{Row: 6, Col: 2}, UnitStats{Attack: 2, Health: 2, Range: 1})
+	b := placeTestUnit(gs, 1, Point{Row: 5, Col: 2}, UnitStats{Attack: 3, Health: 2, Range: 1})
+
+	log := NewEventLog(gs.CurrentTurn)
+	resolveCombat(gs, log)
+	resolveDeaths(gs, log, "death")
+
+	if len(gs.Units) != 0 {
+		t.Fatalf("Expected both units to die, %d remain", len(gs.Units))
+	}
+	if len(gs.PlayerStates[0].DiscardPile) != 1 || gs.PlayerStates[0].DiscardPile[0].InstanceID != a.CardInstanceID {
+		t.Errorf("Player 0 unit card should be discarded, got %v", gs.PlayerStates[0].DiscardPile)
+	}
+	if len(gs.PlayerStates[1].DiscardPile) != 1 || gs.PlayerStates[1].DiscardPile[0].InstanceID != b.CardInstanceID {
+		t.Errorf("Player 1 unit card should be discarded, got %v", gs.PlayerStates[1].DiscardPile)
+	}
+
+	damage := 0
+	for _, evt := range log.Events {
+		if evt.Type == EventTypeDamage {
+			damage++
+			if evt.Data["remainingHp"] != 0 {
+				t.Errorf("Expected lethal hit, got %v", evt.Data)
+			}
+		}
+	}
+	if damage != 2 {
+		t.Errorf("Expected 2 damage events, got %d", damage)
+	}
+}
+
+func TestCombatTargetsClosestUnitThenCommandCenter(t *testing.T) {
+	gs := NewGameState("combat-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	archer := placeTestUnit(gs, 0, Point{Row: 6, Col: 2}, UnitStats{Attack: 1, Health: 5, Range: 2})
+	near := placeTestUnit(gs, 1, Point{Row: 5, Col: 2}, UnitStats{Attack: 0, Health: 5, Range: 1})
+	far := placeTestUnit(gs, 1, Point{Row: 4, Col: 2}, UnitStats{Attack: 0, Health: 5, Range: 1})
+
+	resolveCombat(gs, NewEventLog(gs.CurrentTurn))
+
+	if near.Health != 4 || far.Health != 5 {
+		t.Errorf("Expected the closest enemy to be hit, near=%d far=%d", near.Health, far.Health)
+	}
+	if archer.Health != 5 {
+		t.Errorf("Archer should be untouched, got %d", archer.Health)
+	}
+
+	cc := gs.GetCommandCenter(1)
+	siege := placeTestUnit(gs, 0, Point{Row: cc.TopLeftRow + 2, Col: cc.TopLeftCol}, UnitStats{Attack: 4, Health: 1, Range: 1})
+	log := NewEventLog(gs.CurrentTurn)
+	resolveCombat(gs, log)
+
+	if cc.Health != cc.MaxHealth-4 {
+		t.Errorf("Expected command center to take 4 damage, health=%d", cc.Health)
+	}
+	found := false
+	for _, evt := range log.Events {
+		if evt.Type == EventTypeDamage && evt.Data["attackerId"] == siege.ID {
+			found = evt.Data["targetType"] == "command_center"
+		}
+	}
+	if !found {
+		t.Error("Expected a command center damage event from the sieging unit")
+	}
+}
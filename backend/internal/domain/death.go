@@ -0,0 +1,143 @@
+package domain
+
+// maxDeathPasses bounds chained deaths (a Deathrattle killing another unit with
+// a Deathrattle, and so on) so a resolution can never loop forever.
+const maxDeathPasses = 8
+
+// orthogonalNeighbors returns the four tiles adjacent to p.
+func orthogonalNeighbors(p Point) []Point {
+	return []Point{
+		{Row: p.Row - 1, Col: p.Col},
+		{Row: p.Row + 1, Col: p.Col},
+		{Row: p.Row, Col: p.Col - 1},
+		{Row: p.Row, Col: p.Col + 1},
+	}
+}
+
+// resolveDeaths runs the death-resolution step. Every unit at 0 HP is removed
+// from the board at once and its card instance goes to its owner's discard
+// pile, or back to the command zone with a respawn cooldown for a hero.
+// OnDeath keyword hooks then fire in board order (the order units entered
+// play), and units killed by those triggers are resolved in a further pass.
+// Units still dead after maxDeathPasses leave the board without their hooks.
+func resolveDeaths(gs *GameState, log *EventLog, step string) {
+	// Auras of the dead stop applying once they leave the board
+	defer refreshAuras(gs)
+	for pass := 0; pass < maxDeathPasses; pass++ {
+		dead := deadUnits(gs)
+		if len(dead) == 0 {
+			return
+		}
+		for _, u := range dead {
+			removeDeadUnit(gs, u, log, step, false)
+		}
+		for _, u := range dead {
+			runDeathHooks(gs, u, step, log)
+		}
+	}
+	for _, u := range deadUnits(gs) {
+		removeDeadUnit(gs, u, log, step, true)
+	}
+}
+
+// deadUnits returns the units on the board at 0 HP, in board order.
+func deadUnits(gs *GameState) []*Unit {
+	var dead []*Unit
+	for _, u := range gs.Units {
+		if !u.IsAlive() {
+			dead = append(dead, u)
+		}
+	}
+	return dead
+}
+
+// removeDeadUnit takes a dead unit off the board, sends its card to its zone
+// and logs the death. skipped marks a death whose hooks will not run.
+func removeDeadUnit(gs *GameState, u *Unit, log *EventLog, step string, skipped bool) {
+	gs.RemoveUnit(u.ID)
+	data := map[string]any{
+		"unitId":         u.ID,
+		"playerIndex":    u.PlayerIndex,
+		"cardId":         u.CardID,
+		"cardInstanceId": u.CardInstanceID,
+		"row":            u.Position.Row,
+		"col":            u.Position.Col,
+		"zone":           "discard",
+	}
+	if skipped {
+		data["triggersSkipped"] = true
+	}
+	if u.PlayerIndex >= 0 && u.PlayerIndex < len(gs.PlayerStates) {
+		if u.IsHero {
+			data["zone"] = "command_zone"
+			data["heroCooldown"] = gs.returnHeroToCommandZone(u)
+		} else {
+			ps := &gs.PlayerStates[u.PlayerIndex]
+			ps.DiscardPile = append(ps.DiscardPile, u.CardInstance())
+		}
+	}
+	log.AddSimple(EventTypeDeath, step, data)
+}
+
+// rekindle moves a dead unit's card from the discard pile back to its owner's
+// hand, or onto the top of the draw pile when the hand is at its limit.
+func rekindle(gs *GameState, u *Unit, kw Keyword, step string, log *EventLog) {
+	if u.PlayerIndex < 0 || u.PlayerIndex >= len(gs.PlayerStates) {
+		return
+	}
+	ps := &gs.PlayerStates[u.PlayerIndex]
+	found := false
+	for i := len(ps.DiscardPile) - 1; i >= 0; i-- {
+		if ps.DiscardPile[i].InstanceID == u.CardInstanceID {
+			ps.DiscardPile = append(ps.DiscardPile[:i], ps.DiscardPile[i+1:]...)
+			found = true
+			break
+		}
+	}
+	if !found {
+		return
+	}
+	handLimit := ps.HandLimit
+	if handLimit <= 0 {
+		handLimit = DefaultHandLimit
+	}
+	zone := "hand"
+	if len(ps.Hand) < handLimit {
+		ps.Hand = append(ps.Hand, u.CardInstance())
+	} else {
+		zone = "deck"
+		ps.DrawPile = append(ps.DrawPile, u.CardInstance())
+		ps.DeckCount = len(ps.DrawPile)
+	}
+	log.AddSimple(EventTypeTrigger, step, map[string]any{
+		"trigger":        string(KeywordRekindle),
+		"unitId":         u.ID,
+		"playerIndex":    u.PlayerIndex,
+		"cardInstanceId": u.CardInstanceID,
+		"zone":           zone,
+	})
+}
+
+// deathrattle runs the dead unit's card DeathEffect from the tile where it
+// died. A card without a DeathEffect only logs the trigger.
+func deathrattle(gs *GameState, u *Unit, kw Keyword, step string, log *EventLog) {
+	log.AddSimple(EventTypeTrigger, step, map[string]any{
+		"trigger":     string(KeywordDeathrattle),
+		"unitId":      u.ID,
+		"playerIndex": u.PlayerIndex,
+		"row":         u.Position.Row,
+		"col":         u.Position.Col,
+	})
+	card := gs.LookupCard(u.CardID)
+	if card == nil || card.DeathEffect == nil {
+		return
+	}
+	runEffectOps(&effectContext{
+		gs:          gs,
+		log:         log,
+		step:        step,
+		playerIndex: u.PlayerIndex,
+		cardID:      card.ID,
+		tile:        u.Position,
+	}, card.DeathEffect.Operations)
+}
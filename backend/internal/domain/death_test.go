@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestDeathResolutionRekindleReturnsCardToHand(t *testing.T) {
+	gs := NewGameState("death-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	ghoul := &Card{
+		ID:        "ghoul",
+		Type:      CardTypeUnit,
+		UnitStats: &UnitStats{Attack: 1, Health: 2, Speed: 1, Range: 1},
+		Abilities: []string{"Rekindle", "Melee"},
+	}
+	gs.SetCardCatalog([]*Card{ghoul})
+	gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0, HandLimit: 7}, {PlayerIndex: 1}}
+	u := gs.SpawnUnit(0, CardInstance{InstanceID: "ghoul-1", CardID: "ghoul"}, ghoul, Point{Row: 6, Col: 4})
+	u.Health = 0
+
+	log := NewEventLog(gs.CurrentTurn)
+	resolveDeaths(gs, log, "death")
+
+	ps := &gs.PlayerStates[0]
+	if len(gs.Units) != 0 {
+		t.Fatalf("Expected the ghoul to leave the board")
+	}
+	if len(ps.Hand) != 1 || ps.Hand[0].InstanceID != "ghoul-1" {
+		t.Errorf("Expected the ghoul card back in hand, got %v", ps.Hand)
+	}
+	if len(ps.DiscardPile) != 0 {
+		t.Errorf("Expected empty discard pile, got %v", ps.DiscardPile)
+	}
+
+	var sawDeath, sawRekindle bool
+	for _, evt := range log.Events {
+		if evt.Type == EventTypeDeath {
+			sawDeath = true
+		}
+		if evt.Type == EventTypeTrigger && evt.Data["trigger"] == "rekindle" {
+			if !sawDeath {
+				t.Error("Rekindle should fire after the death is logged")
+			}
+			sawRekindle = evt.Data["zone"] == "hand"
+		}
+	}
+	if !sawRekindle {
+		t.Error("Expected a rekindle trigger event returning the card to hand")
+	}
+}
+
+func TestDeathResolutionRekindleFullHandGoesToDeck(t *testing.T) {
+	gs := NewGameState("death-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	ghoul := &Card{ID: "ghoul", Type: CardTypeUnit, UnitStats: &UnitStats{Health: 1}, Abilities: []string{"Rekindle"}}
+	gs.SetCardCatalog([]*Card{ghoul})
+	gs.PlayerStates = []PlayerBattleState{
+		{PlayerIndex: 0, HandLimit: 1, Hand: []CardInstance{{InstanceID: "other", CardID: "x"}}},
+		{PlayerIndex: 1},
+	}
+	u := gs.SpawnUnit(0, CardInstance{InstanceID: "ghoul-1", CardID: "ghoul"}, ghoul, Point{Row: 6, Col: 4})
+	u.Health = 0
+
+	resolveDeaths(gs, NewEventLog(gs.CurrentTurn), "death")
+
+	ps := &gs.PlayerStates[0]
+	if len(ps.DrawPile) != 1 || ps.DrawPile[len(ps.DrawPile)-1].InstanceID != "ghoul-1" {
+		t.Errorf("Expected the ghoul card on top of the deck, got %v", ps.DrawPile)
+	}
+}
+
+func TestDeathResolutionDeathrattleChains(t *testing.T) {
+	gs := NewGameState("death-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	bomber := &Card{
+		ID:        "bomber",
+		Type:      CardTypeUnit,
+		UnitStats: &UnitStats{Health: 1},
+		Abilities: []string{"Melee", "Deathrattle"},
+		DeathEffect: &SpellEffect{
+			TargetType: "ground",
+			Operations: []EffectOp{{Op: EffectOpAreaAdjacent, Amount: 2}},
+		},
+	}
+	gs.SetCardCatalog([]*Card{bomber})
+	gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0}, {PlayerIndex: 1}}
+
+	first := gs.SpawnUnit(0, NewCardInstance("bomber"), bomber, Point{Row: 6, Col: 4})
+	second := gs.SpawnUnit(1, NewCardInstance("bomber"), bomber, Point{Row: 5, Col: 4})
+	bystander := placeTestUnit(gs, 1, Point{Row: 4, Col: 4}, UnitStats{Health: 5, Armor: 1})
+	first.Health = 0
+
+	resolveDeaths(gs, NewEventLog(gs.CurrentTurn), "death")
+
+	if gs.GetUnit(second.ID) != nil {
+		t.Error("Adjacent bomber should be killed by the first deathrattle")
+	}
+	if bystander.Health != 4 {
+		t.Errorf("Bystander should take 2-1 armor damage from the chained deathrattle, health=%d", bystander.Health)
+	}
+	if len(gs.PlayerStates[0].DiscardPile) != 1 || len(gs.PlayerStates[1].DiscardPile) != 1 {
+		t.Errorf("Both bomber cards should be discarded")
+	}
+}
+
+func TestDeathResolutionRemovesUnitsPastTheChainLimit(t *testing.T) {
+	gs := NewGameState("death-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	bomber := &Card{
+		ID:        "bomber",
+		Type:      CardTypeUnit,
+		UnitStats: &UnitStats{Health: 1},
+		Abilities: []string{"Deathrattle"},
+		DeathEffect: &SpellEffect{
+			TargetType: "ground",
+			Operations: []EffectOp{{Op: EffectOpAreaAdjacent, Amount: 1}},
+		},
+	}
+	gs.SetCardCatalog([]*Card{bomber})
+	gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0}, {PlayerIndex: 1}}
+
+	// A row of bombers, each killing the next, longer than the chain limit
+	var chain []*Unit
+	for col := 0; col < maxDeathPasses+2; col++ {
+		chain = append(chain, gs.SpawnUnit(0, NewCardInstance("bomber"), bomber, Point{Row: 6, Col: col}))
+	}
+	chain[0].Health = 0
+
+	log := NewEventLog(gs.CurrentTurn)
+	resolveDeaths(gs, log, "death")
+
+	for _, u := range gs.Units {
+		if !u.IsAlive() {
+			t.Errorf("Expected no dead units left on the board, found %s", u.ID)
+		}
+	}
+	if gs.GetUnit(chain[maxDeathPasses].ID) != nil {
+		t.Error("Expected the unit killed by the last allowed pass to leave the board")
+	}
+	if gs.GetUnit(chain[maxDeathPasses+1].ID) == nil {
+		t.Error("Expected the unit after the chain limit to survive, as the last death's hooks do not run")
+	}
+	if got := len(gs.PlayerStates[0].DiscardPile); got != maxDeathPasses+1 {
+		t.Errorf("Expected %d bombers in the discard pile, got %d", maxDeathPasses+1, got)
+	}
+	skipped := 0
+	for _, evt := range log.Events {
+		if evt.Type == EventTypeDeath && evt.Data["triggersSkipped"] == true {
+			skipped++
+		}
+	}
+	if skipped != 1 {
+		t.Errorf("Expected one death resolved without its triggers, got %d", skipped)
+	}
+}
@@ -0,0 +1,271 @@
+package domain
+
+// EffectOpType names a primitive operation a spell can perform.
+type EffectOpType string
+
+const (
+	// EffectOpDamage deals Amount damage to the target.
+	EffectOpDamage EffectOpType = "damage"
+	// EffectOpHeal restores up to Amount health to the target.
+	EffectOpHeal EffectOpType = "heal"
+	// EffectOpDraw draws Amount cards for the caster, or for the targeted
+	// unit's owner with EffectTargetUnit.
+	EffectOpDraw EffectOpType = "draw"
+	// EffectOpBuff adds Attack, Health and Speed to the target unit.
+	EffectOpBuff EffectOpType = "buff"
+	// EffectOpAreaLane deals Amount damage to every unit in the targeted column.
+	EffectOpAreaLane EffectOpType = "area_lane"
+	// EffectOpAreaAdjacent deals Amount damage to every unit orthogonally
+	// adjacent to the targeted tile.
+	EffectOpAreaAdjacent EffectOpType = "area_adjacent"
+	// EffectOpIfDies runs Then if the targeted unit is at 0 HP.
+	EffectOpIfDies EffectOpType = "if_dies"
+)
+
+// EffectTarget selects what an operation applies to.
+type EffectTarget string
+
+const (
+	// EffectTargetUnit is the unit on the targeted tile. It is the default for
+	// every operation except draws, which default to the caster.
+	EffectTargetUnit EffectTarget = "unit"
+	// EffectTargetOwnCommandCenter is the caster's command center.
+	EffectTargetOwnCommandCenter EffectTarget = "own_command_center"
+	// EffectTargetCaster is the casting player, e.g. for draws.
+	EffectTargetCaster EffectTarget = "caster"
+)
+
+// EffectDuration says how long a buff lasts. Buffs that expire are applied as
+// statuses; permanent ones are kept on the unit's modifier stack.
+type EffectDuration string
+
+const (
+	// EffectDurationPermanent buffs stay until the unit leaves play.
+	EffectDurationPermanent EffectDuration = ""
+	// EffectDurationRound buffs expire at the end of the round they were applied.
+	EffectDurationRound EffectDuration = "round"
+)
+
+// EffectOp is one primitive step of a spell. Operations run in order.
+type EffectOp struct {
+	Op       EffectOpType   `json:"op"`
+	Target   EffectTarget   `json:"target,omitempty"`
+	Amount   int            `json:"amount,omitempty"`
+	Attack   int            `json:"attack,omitempty"`
+	Health   int            `json:"health,omitempty"`
+	Speed    int            `json:"speed,omitempty"`
+	Duration EffectDuration `json:"duration,omitempty"`
+	// Then holds the operations run when a conditional operation passes
+	Then []EffectOp `json:"then,omitempty"`
+}
+
+// effectContext carries the caster and target a spell resolves against.
+type effectContext struct {
+	gs          *GameState
+	log         *EventLog
+	step        string
+	playerIndex int
+	cardID      CardID
+	tile        Point
+	unit        *Unit
+}
+
+// resolveSpell runs a spell card's operations for the given play action.
+// The target unit is taken from the action's TargetID, falling back to the
+// unit standing on the action's Position when the spell resolves.
+func resolveSpell(gs *GameState, log *EventLog, step string, a Action, card *Card) {
+	ctx := &effectContext{
+		gs:          gs,
+		log:         log,
+		step:        step,
+		playerIndex: a.PlayerIndex,
+		cardID:      card.ID,
+		tile:        a.Position,
+	}
+	if a.TargetID != "" {
+		ctx.unit = gs.GetUnit(UnitID(a.TargetID))
+	}
+	if ctx.unit == nil {
+		ctx.unit = gs.UnitAt(a.Position.Row, a.Position.Col)
+	}
+
+	data := map[string]any{
+		"playerIndex":    a.PlayerIndex,
+		"action":         string(a.Type),
+		"cardId":         card.ID,
+		"cardInstanceId": a.CardInHandID,
+		"row":            a.Position.Row,
+		"col":            a.Position.Col,
+	}
+	if ctx.unit != nil {
+		data["targetId"] = ctx.unit.ID
+	}
+	log.AddSimple(EventTypeEffect, step, data)
+
+	runEffectOps(ctx, card.SpellEffect.Operations)
+}
+
+func runEffectOps(ctx *effectContext, ops []EffectOp) {
+	for _, op := range ops {
+		switch op.Op {
+		case EffectOpDamage:
+			switch op.Target {
+			case EffectTargetOwnCommandCenter:
+				ctx.gs.DealDamageToCommandCenter(ctx.playerIndex, op.Amount)
+			default:
+				if ctx.unit != nil && ctx.unit.IsAlive() {
+					damageUnit(ctx.gs, ctx.unit, op.Amount, string(ctx.cardID), ctx.step, ctx.log)
+				}
+			}
+		case EffectOpHeal:
+			switch op.Target {
+			case EffectTargetOwnCommandCenter:
+				healCommandCenter(ctx, op.Amount)
+			default:
+				if ctx.unit != nil && ctx.unit.IsAlive() {
+					healUnit(ctx, ctx.unit, op.Amount)
+				}
+			}
+		case EffectOpDraw:
+			player := ctx.playerIndex
+			switch op.Target {
+			case EffectTargetUnit:
+				if ctx.unit == nil {
+					continue
+				}
+				player = ctx.unit.PlayerIndex
+			case "", EffectTargetCaster:
+			default:
+				// A command center cannot draw
+				continue
+			}
+			if player < 0 || player >= len(ctx.gs.PlayerStates) {
+				continue
+			}
+			ps := &ctx.gs.PlayerStates[player]
+			if drawn := drawCardsDeterministic(ctx.gs, ps, op.Amount, ctx.log); drawn > 0 {
+				ctx.log.AddSimple(EventTypeDraw, ctx.step, map[string]any{
+					"playerIndex": player,
+					"count":       drawn,
+					"handCount":   len(ps.Hand),
+				})
+			}
+		case EffectOpBuff:
+			if ctx.unit != nil && ctx.unit.IsAlive() {
+				buffUnit(ctx, ctx.unit, op)
+			}
+		case EffectOpAreaLane:
+			// Snapshot the lane first so every unit in it is hit once
+			var lane []*Unit
+			for _, u := range ctx.gs.Units {
+				if u.Position.Col == ctx.tile.Col && u.IsAlive() {
+					lane = append(lane, u)
+				}
+			}
+			for _, u := range lane {
+				damageUnit(ctx.gs, u, op.Amount, string(ctx.cardID), ctx.step, ctx.log)
+			}
+		case EffectOpAreaAdjacent:
+			var adjacent []*Unit
+			for _, p := range orthogonalNeighbors(ctx.tile) {
+				if u := ctx.gs.UnitAt(p.Row, p.Col); u != nil && u.IsAlive() {
+					adjacent = append(adjacent, u)
+				}
+			}
+			for _, u := range adjacent {
+				damageUnit(ctx.gs, u, op.Amount, string(ctx.cardID), ctx.step, ctx.log)
+			}
+		case EffectOpIfDies:
+			if ctx.unit != nil && !ctx.unit.IsAlive() {
+				runEffectOps(ctx, op.Then)
+			}
+		}
+	}
+}
+
+// damageUnit deals damage reduced by the target's armor and logs it.
+// Returns the damage actually dealt.
+func damageUnit(gs *GameState, target *Unit, amount int, sourceID string, step string, log *EventLog) int {
+	dealt := max(0, amount-target.Armor)
+	target.Health = max(0, target.Health-dealt)
+	log.AddSimple(EventTypeDamage, step, map[string]any{
+		"sourceId":          sourceID,
+		"targetType":        "unit",
+		"targetId":          target.ID,
+		"targetPlayerIndex": target.PlayerIndex,
+		"amount":            dealt,
+		"remainingHp":       target.Health,
+	})
+	return dealt
+}
+
+func healUnit(ctx *effectContext, u *Unit, amount int) {
+	healed := min(amount, u.MaxHealth-u.Health)
+	if healed <= 0 {
+		return
+	}
+	u.Health += healed
+	ctx.log.AddSimple(EventTypeEffect, ctx.step, map[string]any{
+		"effect":            string(EffectOpHeal),
+		"sourceId":          ctx.cardID,
+		"targetType":        "unit",
+		"targetId":          u.ID,
+		"targetPlayerIndex": u.PlayerIndex,
+		"amount":            healed,
+		"health":            u.Health,
+	})
+}
+
+func healCommandCenter(ctx *effectContext, amount int) {
+	cc := ctx.gs.GetCommandCenter(ctx.playerIndex)
+	if cc == nil || cc.IsDestroyed() {
+		return
+	}
+	healed := min(amount, cc.MaxHealth-cc.Health)
+	if healed <= 0 {
+		return
+	}
+	cc.Health += healed
+	ctx.log.AddSimple(EventTypeEffect, ctx.step, map[string]any{
+		"effect":            string(EffectOpHeal),
+		"sourceId":          ctx.cardID,
+		"targetType":        "command_center",
+		"targetPlayerIndex": cc.PlayerIndex,
+		"amount":            healed,
+		"health":            cc.Health,
+	})
+}
+
+// buffUnit applies a buff operation to a unit. Buffs lasting this round become
+// statuses that expire in Cleanup; permanent buffs go on the unit's equipment
+// layer.
+func buffUnit(ctx *effectContext, u *Unit, op EffectOp) {
+	ctx.log.AddSimple(EventTypeEffect, ctx.step, map[string]any{
+		"effect":      string(EffectOpBuff),
+		"sourceId":    ctx.cardID,
+		"unitId":      u.ID,
+		"playerIndex": u.PlayerIndex,
+		"attack":      op.Attack,
+		"health":      op.Health,
+		"speed":       op.Speed,
+		"duration":    string(op.Duration),
+	})
+	if op.Duration == EffectDurationRound {
+		for _, s := range []struct {
+			t      StatusType
+			amount int
+		}{{StatusAttack, op.Attack}, {StatusHealth, op.Health}, {StatusSpeed, op.Speed}} {
+			if s.amount != 0 {
+				ctx.gs.ApplyUnitStatus(u, s.t, s.amount, string(ctx.cardID), ctx.gs.CurrentTurn, ctx.step, ctx.log)
+			}
+		}
+		return
+	}
+	u.AddModifier(StatModifier{
+		Layer:    ModifierEquipment,
+		SourceID: string(ctx.cardID),
+		Attack:   op.Attack,
+		Health:   op.Health,
+		Speed:    op.Speed,
+	})
+}
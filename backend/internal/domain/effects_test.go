@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestSpellDamageIfDiesDraws(t *testing.T) {
+	gs := NewGameState("effects-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.SetCardCatalog([]*Card{{
+		ID:   "painful_memories",
+		Type: CardTypeSpell,
+		SpellEffect: &SpellEffect{TargetType: "unit", Operations: []EffectOp{
+			{Op: EffectOpDamage, Amount: 2},
+			{Op: EffectOpIfDies, Then: []EffectOp{{Op: EffectOpDraw, Target: EffectTargetCaster, Amount: 1}}},
+		}},
+	}})
+	gs.PlayerStates = []PlayerBattleState{
+		{
+			PlayerIndex: 0,
+			HandLimit:   7,
+			Hand:        []CardInstance{{InstanceID: "pm-1", CardID: "painful_memories"}},
+			DrawPile:    []CardInstance{{InstanceID: "next", CardID: "x"}},
+		},
+		{PlayerIndex: 1},
+	}
+	victim := placeTestUnit(gs, 1, Point{Row: 4, Col: 3}, UnitStats{Attack: 1, Health: 2, Range: 1})
+	gs.AddPlannedPlay(PlannedPlay{PlayerIndex: 0, CardInstance: "pm-1", CardID: "painful_memories", Position: victim.Position})
+
+	ExecuteResolutionPhase(gs, ActionQueue{}, ActionQueue{})
+
+	if gs.GetUnit(victim.ID) != nil {
+		t.Fatal("Expected the target to die and leave the board")
+	}
+	ps := &gs.PlayerStates[0]
+	if len(ps.Hand) != 1 || ps.Hand[0].InstanceID != "next" {
+		t.Errorf("Expected the caster to draw a card, hand=%v", ps.Hand)
+	}
+	if len(ps.DiscardPile) != 1 || ps.DiscardPile[0].InstanceID != "pm-1" {
+		t.Errorf("Expected the spell in the discard pile, got %v", ps.DiscardPile)
+	}
+}
+
+func TestDrawRespectsTarget(t *testing.T) {
+	gs := NewGameState("effects-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.PlayerStates = []PlayerBattleState{
+		{PlayerIndex: 0, HandLimit: 7, DrawPile: []CardInstance{{InstanceID: "mine", CardID: "x"}}},
+		{PlayerIndex: 1, HandLimit: 7, DrawPile: []CardInstance{{InstanceID: "theirs", CardID: "x"}}},
+	}
+	victim := placeTestUnit(gs, 1, Point{Row: 4, Col: 3}, UnitStats{Health: 2})
+	card := &Card{ID: "insight", Type: CardTypeSpell, SpellEffect: &SpellEffect{TargetType: "unit", Operations: []EffectOp{
+		{Op: EffectOpDraw, Target: EffectTargetUnit, Amount: 1},
+		{Op: EffectOpDraw, Target: EffectTargetOwnCommandCenter, Amount: 1},
+	}}}
+
+	resolveSpell(gs, NewEventLog(gs.CurrentTurn), "fast", Action{PlayerIndex: 0, Type: ActionTypePlayCard, TargetID: string(victim.ID)}, card)
+
+	if hand := gs.PlayerStates[1].Hand; len(hand) != 1 || hand[0].InstanceID != "theirs" {
+		t.Errorf("Expected the targeted unit's owner to draw, got %v", hand)
+	}
+	if hand := gs.PlayerStates[0].Hand; len(hand) != 0 {
+		t.Errorf("Expected the caster not to draw, got %v", hand)
+	}
+}
+
+func TestSpellBuffExpiresAtEndOfRound(t *testing.T) {
+	gs := NewGameState("effects-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	card := &Card{
+		ID:   "bloodlust",
+		Type: CardTypeSpell,
+		SpellEffect: &SpellEffect{TargetType: "unit", Operations: []EffectOp{
+			{Op: EffectOpBuff, Attack: 2, Speed: 1, Duration: EffectDurationRound},
+		}},
+	}
+	u := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 2, Speed: 1, Range: 1})
+	log := NewEventLog(gs.CurrentTurn)
+
+	resolveSpell(gs, log, "fast", Action{PlayerIndex: 0, Type: ActionTypePlayCard, TargetID: string(u.ID)}, card)
+	if u.Attack != 3 || u.Speed != 2 {
+		t.Fatalf("Expected +2 ATK and +1 SPD, got atk=%d spd=%d", u.Attack, u.Speed)
+	}
+
+	if len(gs.Statuses) != 2 {
+		t.Fatalf("Expected the round buff as two statuses, got %v", gs.Statuses)
+	}
+
+	expireStatuses(gs, log)
+	if u.Attack != 1 || u.Speed != 1 || len(gs.Statuses) != 0 {
+		t.Errorf("Expected round buff to expire, got atk=%d spd=%d statuses=%v", u.Attack, u.Speed, gs.Statuses)
+	}
+}
+
+func TestSpellAreaLaneHitsOnlyTargetColumn(t *testing.T) {
+	gs := NewGameState("effects-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	card := &Card{
+		ID:          "miasma",
+		Type:        CardTypeSpell,
+		SpellEffect: &SpellEffect{TargetType: "lane", Operations: []EffectOp{{Op: EffectOpAreaLane, Amount: 1}}},
+	}
+	a := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Health: 3})
+	b := placeTestUnit(gs, 1, Point{Row: 3, Col: 3}, UnitStats{Health: 3})
+	c := placeTestUnit(gs, 1, Point{Row: 3, Col: 4}, UnitStats{Health: 3})
+
+	resolveSpell(gs, NewEventLog(gs.CurrentTurn), "fast", Action{PlayerIndex: 0, Position: Point{Row: 5, Col: 3}}, card)
+
+	if a.Health != 2 || b.Health != 2 {
+		t.Errorf("Expected both units in the lane to take 1 damage, got %d and %d", a.Health, b.Health)
+	}
+	if c.Health != 3 {
+		t.Errorf("Unit outside the lane should be untouched, got %d", c.Health)
+	}
+}
@@ -10,17 +10,23 @@ const (
     EventTypeResource   EventType = "resource"
     EventTypeDraw       EventType = "draw"
     EventTypeMovement   EventType = "movement"
+    EventTypeCollision  EventType = "collision"
     EventTypeDamage     EventType = "damage"
+    EventTypeDeath      EventType = "death"
     EventTypeEffect     EventType = "effect"
     EventTypeDiscard    EventType = "discard"
+    EventTypeSummon     EventType = "summon"
+    EventTypeStatusApplied EventType = "status_applied"
+    EventTypeStatusExpired EventType = "status_expired"
     EventTypeRoundStart EventType = "round_start"
     EventTypeRoundEnd   EventType = "round_end"
+    EventTypeMatchEnd   EventType = "match_end"
 )
 
 // Event represents one item in the resolution timeline sent to clients.
 type Event struct {
     Type      EventType            `json:"type"`
-    Step      string               `json:"step"`       // e.g., upkeep, fast, movement, combat, slow, end_of_round
+    Step      string               `json:"step"`       // e.g., upkeep, fast, summon, movement, combat, slow, end_of_round
     Timestamp time.Time            `json:"timestamp"`
     Data      map[string]any       `json:"data,omitempty"`
 }
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"math/rand"
 	"time"
 )
 
@@ -50,10 +51,20 @@ type PlayerBattleState struct {
 	Resources   Resources  `json:"resources"`
 	// Resource income per turn (from buildings)
 	ResourceIncome ResourceGeneration `json:"resourceIncome"`
+	// CommandZone holds the hero card while it is off the board; it never
+	// enters the draw pile
+	CommandZone []CardInstance `json:"commandZone"`
+	// HeroCooldown is the number of rounds before the hero can be redeployed
+	HeroCooldown int `json:"heroCooldown"`
 	// Limits
 	HandLimit   int        `json:"handLimit"`
 	// Queues - using instance IDs for discards
 	PendingDiscards []CardInstanceID   `json:"-"`
+	// DiscardRequired is how many cards the player must discard during Cleanup
+	// to get back to their hand limit
+	DiscardRequired int `json:"discardRequired"`
+	// CleanupDiscards are the cards chosen for that discard; nil until chosen
+	CleanupDiscards []CardInstanceID `json:"-"`
 }
 
 // CommandCenter represents a player's command center with health and building functionality.
@@ -61,17 +72,24 @@ type CommandCenter struct {
 	PlayerIndex int                `json:"playerIndex"`
 	TopLeftRow  int                `json:"topLeftRow"`
 	TopLeftCol  int                `json:"topLeftCol"`
+	// Footprint is the tiles the command center covers from its top-left tile
+	Footprint   Footprint          `json:"footprint"`
 	Health      int                `json:"health"`
 	MaxHealth   int                `json:"maxHealth"`
 	Building    *Building          `json:"building"`
 }
 
-// NewCommandCenter creates a new command center with default health and building.
+// DefaultCommandCenterFootprint is the command center size used when a map
+// does not give one.
+var DefaultCommandCenterFootprint = Footprint{Width: 2, Height: 2}
+
+// NewCommandCenter creates a new command center with default health, footprint and building.
 func NewCommandCenter(playerIndex, topLeftRow, topLeftCol int) *CommandCenter {
 	return &CommandCenter{
 		PlayerIndex: playerIndex,
 		TopLeftRow:  topLeftRow,
 		TopLeftCol:  topLeftCol,
+		Footprint:   DefaultCommandCenterFootprint,
 		Health:      100,
 		MaxHealth:   100,
 		Building:    NewBuilding(BuildingCommandCenter, playerIndex, topLeftRow, topLeftCol),
@@ -93,12 +111,25 @@ func (cc *CommandCenter) IsDestroyed() bool {
 	return cc.Health <= 0
 }
 
+// TopLeft returns the command center's top-left tile.
+func (cc *CommandCenter) TopLeft() Point {
+	return Point{Row: cc.TopLeftRow, Col: cc.TopLeftCol}
+}
+
+// Covers reports whether the command center's footprint covers the tile.
+func (cc *CommandCenter) Covers(row, col int) bool {
+	return cc.Footprint.covers(cc.TopLeft(), row, col)
+}
+
 // GameState represents the current state of a game.
 type GameState struct {
 	ID                  GameID           `json:"id"`
 	Status              GameStatus       `json:"status"`
 	Players             []Player         `json:"players"`
 	CommandCenters      []*CommandCenter `json:"commandCenters"`
+	// Teams is each player's team, by player index. Players on the same team
+	// are allies and win together
+	Teams               []int            `json:"teams"`
 	PlayerStates        []PlayerBattleState `json:"playerStates"`
 	CurrentTurn         int              `json:"currentTurn"`
 	CurrentPhase        GamePhase        `json:"currentPhase"`
@@ -106,19 +137,62 @@ type GameState struct {
 	TurnCount           int              `json:"turnCount"`
 	BoardRows           int              `json:"boardRows"`
 	BoardCols           int              `json:"boardCols"`
+	// MapID names the map definition the board was laid out from, if any
+	MapID               MapID            `json:"mapId,omitempty"`
+	// Terrain is each tile's ground type, [row][col]
+	Terrain             [][]Terrain      `json:"terrain"`
 	PlayerChoicesLocked map[int]bool     `json:"playerChoicesLocked"`
 	PendingActions      map[int]ActionQueue `json:"-"`
     // PlannedPlays are the staged plays during Planning phase, exposed to clients
     PlannedPlays        map[int][]PlannedPlay `json:"plannedPlays"`
+	// Zones are each player's base/deployment/neutral/side-buffer tile maps
+	Zones               []PlayerZones    `json:"zones"`
+	// Structures are the buildings on the board other than command centers
+	Structures          []*Structure     `json:"structures"`
+	// Units are the unit instances currently on the board
+	Units               []*Unit          `json:"units"`
+	// Statuses are the temporary effects on units and structures
+	Statuses            []StatusEffect   `json:"statuses"`
+	// Cards is the card catalog plays are resolved against (server-side only)
+	Cards               map[CardID]*Card `json:"-"`
+	// PriorityPlayer holds the priority token and wins same-speed ties this round
+	PriorityPlayer      int              `json:"priorityPlayer"`
+	// Seed is the match seed every random choice is derived from
+	Seed                int64            `json:"seed"`
+	// TurnLimit is the number of rounds after which the match is decided on
+	// command center HP; 0 means no limit
+	TurnLimit           int              `json:"turnLimit"`
+	// Conceded lists the players who have forfeited
+	Conceded            []int            `json:"conceded,omitempty"`
+	// Result is set once the match has ended
+	Result              *MatchResult     `json:"result,omitempty"`
 	CreatedAt           time.Time        `json:"createdAt"`
 	UpdatedAt           time.Time        `json:"updatedAt"`
+
+	// rng is the match's single random stream, derived from Seed
+	rng *rand.Rand
+	// unitSeq and structureSeq are used to assign board-unique IDs
+	unitSeq      int
+	structureSeq int
+	statusSeq    int
 }
 
-// NewGameState creates a new game state with default command centers.
+// NewGameState creates a new game state with a default command center and
+// starter Tower and Barracks for each player, every player on their own team.
 func NewGameState(gameID GameID, players []Player, boardRows, boardCols int) *GameState {
-	commandCenters := computeDefaultCommandCenters(boardRows, boardCols)
-	
-	return &GameState{
+	commandCenters := computeDefaultCommandCenters(boardRows, boardCols, max(len(players), 2))
+	// Matches are unseeded by default; callers that need a reproducible match
+	// call SetSeed before anything random happens
+	gs := newGameState(gameID, players, boardRows, boardCols, commandCenters, nil, time.Now().UnixNano(), nil)
+	gs.placeStarterStructures()
+	return gs
+}
+
+// newGameState creates a game state around the given command centers with
+// default zones and no other structures, seeded and with its terrain laid out
+// once from the seed. A nil teams puts every player on a team of their own.
+func newGameState(gameID GameID, players []Player, boardRows, boardCols int, commandCenters []*CommandCenter, teams []int, seed int64, terrain *MapTerrain) *GameState {
+	gs := &GameState{
 		ID:                  gameID,
 		Status:              GameStatusWaiting,
 		Players:             players,
@@ -130,19 +204,30 @@ func NewGameState(gameID GameID, players []Player, boardRows, boardCols int) *Ga
 		TurnCount:           0,
 		BoardRows:           boardRows,
 		BoardCols:           boardCols,
-		PlayerChoicesLocked: map[int]bool{0: false, 1: false},
-		PendingActions:      map[int]ActionQueue{0: {}, 1: {}},
-        PlannedPlays:        map[int][]PlannedPlay{0: []PlannedPlay{}, 1: []PlannedPlay{}},
+		Teams:               teams,
+		Structures:          []*Structure{},
+		Units:               []*Unit{},
 		CreatedAt:           time.Now(),
 		UpdatedAt:           time.Now(),
 	}
-}
-
-// computeDefaultCommandCenters creates the default command center positions.
-func computeDefaultCommandCenters(rows, cols int) []*CommandCenter {
+	if gs.Teams == nil {
+		gs.Teams = defaultTeams(gs.PlayerCount())
+	}
+	gs.resetPlayerChoices()
+	gs.Zones = computeDefaultZones(boardRows, boardCols, commandCenters, gs.AreAllies)
+	gs.SetSeed(seed)
+	gs.buildTerrain(terrain)
+	return gs
+}
+
+// computeDefaultCommandCenters creates the default command center positions
+// for n players. Even-numbered players take the bottom side and odd-numbered
+// players the top, and the command centers on each side are spread evenly
+// across the board, so two players face each other on the middle column.
+func computeDefaultCommandCenters(rows, cols, n int) []*CommandCenter {
     // Desired bottom-center (southern) tile positions for a 2x2 footprint:
-    // Player 0 (top side):    (row=11, col=6)
-    // Player 1 (bottom side): (row=1,  col=6)
+    // bottom side: (row=rows-1, col=(k+1)*cols/(perSide+1))
+    // top side:    (row=1,      col=(k+1)*cols/(perSide+1))
     // Given a 2x2 footprint, the bottom-center anchor is at (topLeftRow+1, topLeftCol+0.5).
     // So we compute top-left as (row-1, col-1) and clamp into the board.
 
@@ -166,17 +251,19 @@ func computeDefaultCommandCenters(rows, cols int) []*CommandCenter {
         return tlr, tlc
     }
 
-    // Target anchors
-    p0BottomRow, p0BottomCol := 11, 6
-    p1BottomRow, p1BottomCol := 1, 6
-
-    p0TLR, p0TLC := clampTopLeft(p0BottomRow, p0BottomCol)
-    p1TLR, p1TLC := clampTopLeft(p1BottomRow, p1BottomCol)
-
-    return []*CommandCenter{
-        NewCommandCenter(0, p0TLR, p0TLC),
-        NewCommandCenter(1, p1TLR, p1TLC),
+    // Players per side: the bottom side takes the extra one when n is odd
+    perSide := [2]int{(n + 1) / 2, n / 2}
+    commandCenters := make([]*CommandCenter, 0, n)
+    for i := 0; i < n; i++ {
+        side, k := i%2, i/2
+        bottomRow := rows - 1
+        if side == 1 {
+            bottomRow = 1
+        }
+        tlr, tlc := clampTopLeft(bottomRow, (k+1)*cols/(perSide[side]+1))
+        commandCenters = append(commandCenters, NewCommandCenter(i, tlr, tlc))
     }
+    return commandCenters
 }
 
 // GetCommandCenter returns the command center for the specified player.
@@ -200,30 +287,30 @@ func (gs *GameState) DealDamageToCommandCenter(playerIndex, damage int) bool {
 	destroyed := cc.TakeDamage(damage)
 	gs.UpdatedAt = time.Now()
 	
-	if destroyed {
-		gs.Status = GameStatusFinished
-	}
-	
+	// The match itself is only ended by CheckMatchEnd, once every hit of the
+	// step has landed
 	return destroyed
 }
 
-// IsGameOver returns true if any command center is destroyed.
+// IsGameOver returns true once the match has a result, or a player is out and
+// at most one team still has a standing command center.
 func (gs *GameState) IsGameOver() bool {
-	for _, cc := range gs.CommandCenters {
-		if cc.IsDestroyed() {
-			return true
+	if gs.Result != nil {
+		return true
+	}
+	for i := 0; i < gs.PlayerCount(); i++ {
+		if gs.IsEliminated(i) {
+			return len(gs.teamsAlive()) <= 1
 		}
 	}
 	return false
 }
 
-// GetWinner returns the player index of the winner, or -1 if no winner yet.
+// GetWinner returns the lowest player index on the winning team, or -1 if no
+// winner yet. Use GetWinners for every player on the team.
 func (gs *GameState) GetWinner() int {
-	for _, cc := range gs.CommandCenters {
-		if cc.IsDestroyed() {
-			// The other player wins
-			return 1 - cc.PlayerIndex
-		}
+	if winners := gs.GetWinners(); len(winners) > 0 {
+		return winners[0]
 	}
 	return -1
 }
@@ -239,7 +326,7 @@ func (gs *GameState) StartGame() {
 // LockPlayerChoice marks a player's choice as locked for the current turn.
 func (gs *GameState) LockPlayerChoice(playerIndex int) {
 	if gs.PlayerChoicesLocked == nil {
-		gs.PlayerChoicesLocked = map[int]bool{0: false, 1: false}
+		gs.PlayerChoicesLocked = map[int]bool{}
 	}
 	gs.PlayerChoicesLocked[playerIndex] = true
 	gs.UpdatedAt = time.Now()
@@ -253,13 +340,14 @@ func (gs *GameState) IsPlayerLocked(playerIndex int) bool {
 	return gs.PlayerChoicesLocked[playerIndex]
 }
 
-// AreAllPlayersLocked returns true if all players have locked their choices.
+// AreAllPlayersLocked returns true if every player still in the match has
+// locked their choices. Eliminated players are not waited on.
 func (gs *GameState) AreAllPlayersLocked() bool {
 	if gs.PlayerChoicesLocked == nil {
 		return false
 	}
-	for _, locked := range gs.PlayerChoicesLocked {
-		if !locked {
+	for i := 0; i < gs.PlayerCount(); i++ {
+		if !gs.PlayerChoicesLocked[i] && !gs.IsEliminated(i) {
 			return false
 		}
 	}
@@ -273,28 +361,10 @@ func (gs *GameState) AdvanceTurn() {
 	// Reset phase to Draw & Income for new turn
 	gs.CurrentPhase = PhaseDrawIncome
 	gs.PhaseStartTime = time.Now()
-	// Reset all player locks for the new turn
-	if gs.PlayerChoicesLocked == nil {
-		gs.PlayerChoicesLocked = map[int]bool{0: false, 1: false}
-	} else {
-		for key := range gs.PlayerChoicesLocked {
-			gs.PlayerChoicesLocked[key] = false
-		}
-	}
-	// Clear pending actions for new turn
-	if gs.PendingActions == nil {
-		gs.PendingActions = map[int]ActionQueue{0: {}, 1: {}}
-	} else {
-		gs.PendingActions[0] = ActionQueue{}
-		gs.PendingActions[1] = ActionQueue{}
-	}
-    // Clear planned plays
-    if gs.PlannedPlays == nil {
-        gs.PlannedPlays = map[int][]PlannedPlay{0: {}, 1: {}}
-    } else {
-        gs.PlannedPlays[0] = []PlannedPlay{}
-        gs.PlannedPlays[1] = []PlannedPlay{}
-    }
+	// The priority token alternates each round
+	gs.passPriority()
+	// Reset every player's lock, pending actions and planned plays for the new turn
+	gs.resetPlayerChoices()
 	
 	// Process building upgrades first, then resource generation
 	// This ensures upgraded buildings generate their new resource amounts
@@ -351,18 +421,19 @@ func (gs *GameState) DiscardCards(playerIndex int, instanceIDs []CardInstanceID)
 }
 
 // IsTileOccupied returns true if the given tile currently contains a structure
-// that occupies the space. For now, only Command Centers occupy tiles.
+// or unit that occupies the space.
 func (gs *GameState) IsTileOccupied(row, col int) bool {
-    for _, cc := range gs.CommandCenters {
-        if row >= cc.TopLeftRow && row < cc.TopLeftRow+2 &&
-            col >= cc.TopLeftCol && col < cc.TopLeftCol+2 {
-            return true
-        }
+    if gs.isTileBlocked(row, col) {
+        return true
     }
-    // Also treat any existing planned play position as occupied to avoid conflicts
+    // Also treat any existing planned play position as occupied to avoid conflicts.
+    // Spells and orders only aim at a tile, so they do not claim it.
     if gs.PlannedPlays != nil {
         for _, plays := range gs.PlannedPlays {
             for _, p := range plays {
+                if card := gs.LookupCard(p.CardID); card != nil && (card.IsSpell() || card.IsOrder()) {
+                    continue
+                }
                 if p.Position.Row == row && p.Position.Col == col {
                     return true
                 }
@@ -376,7 +447,7 @@ func (gs *GameState) IsTileOccupied(row, col int) bool {
 // plan for the same card instance.
 func (gs *GameState) AddPlannedPlay(play PlannedPlay) {
     if gs.PlannedPlays == nil {
-        gs.PlannedPlays = map[int][]PlannedPlay{0: {}, 1: {}}
+        gs.PlannedPlays = map[int][]PlannedPlay{}
     }
     // Remove any existing plan with the same instance id for that player
     existing := gs.PlannedPlays[play.PlayerIndex]
@@ -410,23 +481,62 @@ func (gs *GameState) RemovePlannedPlay(playerIndex int, cardInstanceID CardInsta
 // ClearPlayerPlannedPlays removes all planned plays for a specific player.
 func (gs *GameState) ClearPlayerPlannedPlays(playerIndex int) {
     if gs.PlannedPlays == nil {
-        gs.PlannedPlays = map[int][]PlannedPlay{0: {}, 1: {}}
+        gs.PlannedPlays = map[int][]PlannedPlay{}
     }
     gs.PlannedPlays[playerIndex] = []PlannedPlay{}
     gs.UpdatedAt = time.Now()
 }
 
-// ClearPlannedPlays removes all planned plays for both players.
+// ClearPlannedPlays removes all planned plays for every player.
 func (gs *GameState) ClearPlannedPlays() {
-    if gs.PlannedPlays == nil {
-        gs.PlannedPlays = map[int][]PlannedPlay{0: {}, 1: {}}
-        return
+    gs.PlannedPlays = map[int][]PlannedPlay{}
+    for i := 0; i < gs.PlayerCount(); i++ {
+        gs.PlannedPlays[i] = []PlannedPlay{}
     }
-    gs.PlannedPlays[0] = []PlannedPlay{}
-    gs.PlannedPlays[1] = []PlannedPlay{}
     gs.UpdatedAt = time.Now()
 }
 
+// PlannedCost returns the combined cost of a player's planned plays and queued
+// activated abilities, skipping the given card instance since staging it again
+// replaces its existing plan.
+func (gs *GameState) PlannedCost(playerIndex int, except CardInstanceID) Resources {
+    total := gs.plannedCardCost(playerIndex, except)
+    abilities := gs.pendingAbilityCost(playerIndex)
+    total.Gold += abilities.Gold
+    total.Mana += abilities.Mana
+    return total
+}
+
+// plannedCardCost totals the cost of the player's staged card plays, skipping
+// the instance except.
+func (gs *GameState) plannedCardCost(playerIndex int, except CardInstanceID) Resources {
+    var total Resources
+    if gs.PlannedPlays == nil {
+        return total
+    }
+    for _, p := range gs.PlannedPlays[playerIndex] {
+        if p.CardInstance == except {
+            continue
+        }
+        if card := gs.LookupCard(p.CardID); card != nil {
+            total.Gold += card.GoldCost
+            total.Mana += card.ManaCost
+        }
+    }
+    return total
+}
+
+// CanAffordPlay reports whether a player can pay for card on top of everything
+// they have already planned this round.
+func (gs *GameState) CanAffordPlay(playerIndex int, instance CardInstanceID, card *Card) bool {
+    if card == nil {
+        return true
+    }
+    planned := gs.PlannedCost(playerIndex, instance)
+    have := gs.GetPlayerResources(playerIndex)
+    return planned.Gold+card.GoldCost <= have.Gold && planned.Mana+card.ManaCost <= have.Mana
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -465,6 +575,15 @@ func (gs *GameState) ProcessResourceGeneration() {
 			totalIncome.Mana += income.Mana
 		}
 		
+		// Get resources from the player's other standing structures
+		for _, st := range gs.PlayerStructures(i) {
+			if st.Building != nil {
+				income := st.Building.GetResourceGeneration()
+				totalIncome.Gold += income.Gold
+				totalIncome.Mana += income.Mana
+			}
+		}
+		
 		// Update player's resource income (for display)
 		playerState.ResourceIncome = totalIncome
 		
@@ -0,0 +1,135 @@
+package domain
+
+// GetDrawableCards returns the cards that are shuffled into the draw pile:
+// everything in the deck except the hero, which starts in the command zone.
+func (d *Deck) GetDrawableCards() []DeckCardEntry {
+	var cards []DeckCardEntry
+	for _, entry := range d.GetAllCards() {
+		if entry.CardID == d.HeroCardID {
+			continue
+		}
+		cards = append(cards, entry)
+	}
+	return cards
+}
+
+// BoardStats returns the stats a card's unit enters the board with. Heroes
+// use their HeroStats; other cards without UnitStats return nil.
+func (c *Card) BoardStats() *UnitStats {
+	if c.UnitStats != nil {
+		return c.UnitStats
+	}
+	if c.IsHero() && c.HeroStats != nil {
+		return &UnitStats{
+			Attack: c.HeroStats.Attack,
+			Health: c.HeroStats.Health,
+			Armor:  c.HeroStats.Armor,
+			Speed:  c.HeroStats.Speed,
+			Range:  c.HeroStats.Range,
+		}
+	}
+	return nil
+}
+
+// FindCard returns a card instance held in the player's hand or command zone,
+// and whether it is the hero in the command zone.
+func (ps *PlayerBattleState) FindCard(id CardInstanceID) (inst CardInstance, inCommandZone bool, ok bool) {
+	for _, c := range ps.Hand {
+		if c.InstanceID == id {
+			return c, false, true
+		}
+	}
+	for _, c := range ps.CommandZone {
+		if c.InstanceID == id {
+			return c, true, true
+		}
+	}
+	return CardInstance{}, false, false
+}
+
+// PlayableCard returns a card instance the player may stage this round: any
+// card in hand, or the hero in the command zone once its cooldown is over.
+func (ps *PlayerBattleState) PlayableCard(id CardInstanceID) (CardInstance, bool) {
+	inst, inCommandZone, ok := ps.FindCard(id)
+	if !ok || (inCommandZone && ps.HeroCooldown > 0) {
+		return CardInstance{}, false
+	}
+	return inst, true
+}
+
+// takePlayableCard removes a playable card instance from the hand or command zone.
+func (ps *PlayerBattleState) takePlayableCard(id CardInstanceID) (CardInstance, bool) {
+	inst, ok := ps.PlayableCard(id)
+	if !ok {
+		return CardInstance{}, false
+	}
+	for i, c := range ps.Hand {
+		if c.InstanceID == id {
+			ps.Hand = append(ps.Hand[:i], ps.Hand[i+1:]...)
+			return inst, true
+		}
+	}
+	for i, c := range ps.CommandZone {
+		if c.InstanceID == id {
+			ps.CommandZone = append(ps.CommandZone[:i], ps.CommandZone[i+1:]...)
+			return inst, true
+		}
+	}
+	return CardInstance{}, false
+}
+
+// returnHeroToCommandZone puts a dead hero's card back in its owner's command
+// zone and starts the respawn countdown from the card's HeroStats.Cooldown.
+func (gs *GameState) returnHeroToCommandZone(u *Unit) int {
+	ps := &gs.PlayerStates[u.PlayerIndex]
+	ps.CommandZone = append(ps.CommandZone, u.CardInstance())
+	cooldown := 0
+	if card := gs.LookupCard(u.CardID); card != nil && card.HeroStats != nil {
+		cooldown = card.HeroStats.Cooldown
+	}
+	ps.HeroCooldown = cooldown
+	return cooldown
+}
+
+// tickHeroCooldowns counts every player's hero respawn cooldown down by one round.
+func tickHeroCooldowns(gs *GameState, log *EventLog) {
+	for i := range gs.PlayerStates {
+		ps := &gs.PlayerStates[i]
+		if ps.HeroCooldown <= 0 {
+			continue
+		}
+		ps.HeroCooldown--
+		log.AddSimple(EventTypeTrigger, "upkeep", map[string]any{
+			"note":         "hero_cooldown",
+			"playerIndex":  ps.PlayerIndex,
+			"heroCooldown": ps.HeroCooldown,
+		})
+	}
+}
+
+// ApplyHeroPassives applies the hero card's passive modifiers to a player at
+// match start. It expects the player's state and starter structures to exist.
+func (gs *GameState) ApplyHeroPassives(playerIndex int, hero *Card) {
+	if hero == nil || hero.HeroStats == nil || playerIndex < 0 || playerIndex >= len(gs.PlayerStates) {
+		return
+	}
+	ps := &gs.PlayerStates[playerIndex]
+	for _, p := range hero.HeroStats.Passives {
+		switch p.Type {
+		case HeroPassiveStartingGold:
+			ps.Resources.Gold += p.Value
+		case HeroPassiveHandLimit:
+			if ps.HandLimit <= 0 {
+				ps.HandLimit = DefaultHandLimit
+			}
+			ps.HandLimit += p.Value
+		case HeroPassiveTowerMana:
+			for _, st := range gs.PlayerStructures(playerIndex) {
+				if st.Building != nil && st.Building.Type == BuildingTower {
+					st.Building.Bonus.Mana += p.Value
+					st.Building.ResourceGen = st.Building.GetResourceGeneration()
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestDeckDrawableCardsExcludeHero(t *testing.T) {
+	deck := &Deck{
+		HeroCardID:          "hero",
+		HeroSignatureCardID: "signature",
+		PawnCards:           []DeckCardEntry{{CardID: "pawn", Quantity: 10}},
+	}
+	for _, entry := range deck.GetDrawableCards() {
+		if entry.CardID == "hero" {
+			t.Fatal("Hero should not be part of the draw pile")
+		}
+	}
+	if got := len(deck.GetDrawableCards()); got != 2 {
+		t.Errorf("Expected signature and pawn entries, got %d", got)
+	}
+}
+
+func TestHeroDeploysFromCommandZoneAndRespawnsAfterCooldown(t *testing.T) {
+	gs := NewGameState("hero-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	hero := &Card{
+		ID:        "hero",
+		Type:      CardTypeHero,
+		HeroStats: &HeroStats{Attack: 3, Health: 7, Speed: 1, Range: 1, Cooldown: 2},
+		Abilities: []string{"Melee"},
+	}
+	gs.SetCardCatalog([]*Card{hero})
+	gs.PlayerStates = []PlayerBattleState{
+		{PlayerIndex: 0, CommandZone: []CardInstance{{InstanceID: "hero-1", CardID: "hero"}}},
+		{PlayerIndex: 1},
+	}
+
+	gs.AddPlannedPlay(PlannedPlay{PlayerIndex: 0, CardInstance: "hero-1", CardID: "hero", Position: Point{Row: 9, Col: 2}})
+	ExecuteResolutionPhase(gs, ActionQueue{}, ActionQueue{})
+
+	ps := &gs.PlayerStates[0]
+	if len(gs.Units) != 1 || !gs.Units[0].IsHero || gs.Units[0].Health != 7 {
+		t.Fatalf("Expected the hero on the board with its hero stats, got %+v", gs.Units)
+	}
+	if len(ps.CommandZone) != 0 {
+		t.Errorf("Command zone should be empty while the hero is deployed, got %v", ps.CommandZone)
+	}
+
+	gs.Units[0].Health = 0
+	resolveDeaths(gs, NewEventLog(gs.CurrentTurn), "death")
+
+	if len(ps.CommandZone) != 1 || ps.CommandZone[0].InstanceID != "hero-1" {
+		t.Fatalf("Dead hero should return to the command zone, got %v", ps.CommandZone)
+	}
+	if len(ps.DiscardPile) != 0 {
+		t.Errorf("Hero should not be discarded, got %v", ps.DiscardPile)
+	}
+	if ps.HeroCooldown != 2 {
+		t.Errorf("Expected a 2 round cooldown, got %d", ps.HeroCooldown)
+	}
+	if _, ok := ps.PlayableCard("hero-1"); ok {
+		t.Error("Hero should not be playable while on cooldown")
+	}
+
+	ExecuteUpkeepPhase(gs)
+	ExecuteUpkeepPhase(gs)
+	if _, ok := ps.PlayableCard("hero-1"); !ok {
+		t.Errorf("Hero should be playable once the cooldown ends, cooldown=%d", ps.HeroCooldown)
+	}
+}
+
+func TestApplyHeroPassives(t *testing.T) {
+	gs := NewGameState("hero-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0, HandLimit: 7}, {PlayerIndex: 1, HandLimit: 7}}
+	warlord := &Card{ID: "warlord", Type: CardTypeHero, HeroStats: &HeroStats{Passives: []HeroPassive{
+		{Type: HeroPassiveStartingGold, Value: 2},
+	}}}
+	spirit := &Card{ID: "spirit", Type: CardTypeHero, HeroStats: &HeroStats{Passives: []HeroPassive{
+		{Type: HeroPassiveHandLimit, Value: 1},
+		{Type: HeroPassiveTowerMana, Value: 1},
+	}}}
+
+	gs.ApplyHeroPassives(0, warlord)
+	gs.ApplyHeroPassives(1, spirit)
+	gs.ProcessResourceGeneration()
+
+	p0, p1 := gs.PlayerStates[0], gs.PlayerStates[1]
+	if p0.Resources.Gold != p1.Resources.Gold+2 {
+		t.Errorf("Expected player 0 to start with 2 extra gold, got %d vs %d", p0.Resources.Gold, p1.Resources.Gold)
+	}
+	if p0.HandLimit != 7 || p1.HandLimit != 8 {
+		t.Errorf("Expected hand limits 7 and 8, got %d and %d", p0.HandLimit, p1.HandLimit)
+	}
+	if p1.ResourceIncome.Mana != p0.ResourceIncome.Mana+1 {
+		t.Errorf("Expected player 1's tower to make 1 extra mana, got %d vs %d", p1.ResourceIncome.Mana, p0.ResourceIncome.Mana)
+	}
+}
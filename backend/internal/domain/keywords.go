@@ -0,0 +1,449 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// KeywordID identifies a keyword understood by the engine.
+type KeywordID string
+
+const (
+	KeywordMelee       KeywordID = "melee"
+	KeywordRanged      KeywordID = "ranged"
+	KeywordFlying      KeywordID = "flying"
+	KeywordHaste       KeywordID = "haste"
+	KeywordPhasing     KeywordID = "phasing"
+	KeywordStealth     KeywordID = "stealth"
+	KeywordTaunt       KeywordID = "taunt"
+	KeywordProtector   KeywordID = "protector"
+	KeywordAura        KeywordID = "aura"
+	KeywordSummon      KeywordID = "summon"
+	KeywordRekindle    KeywordID = "rekindle"
+	KeywordDeathrattle KeywordID = "deathrattle"
+	KeywordRegenerate  KeywordID = "regenerate"
+	KeywordLifesteal   KeywordID = "lifesteal"
+	KeywordArmor       KeywordID = "armor"
+	KeywordPierce      KeywordID = "pierce"
+	KeywordSiege       KeywordID = "siege"
+	KeywordSplash      KeywordID = "splash"
+	KeywordSlow        KeywordID = "slow"
+	KeywordRoot        KeywordID = "root"
+	KeywordEvade       KeywordID = "evade"
+	KeywordKnockback   KeywordID = "knockback"
+	KeywordCharge      KeywordID = "charge"
+	KeywordOverwatch   KeywordID = "overwatch"
+	KeywordRange       KeywordID = "range"
+	KeywordSpeed       KeywordID = "speed"
+	KeywordDraw        KeywordID = "draw"
+	KeywordCriticalHit KeywordID = "critical_hit"
+	KeywordMultiStrike KeywordID = "multi_strike"
+	KeywordBerserk     KeywordID = "berserk"
+	KeywordLucky       KeywordID = "lucky"
+	KeywordHaunt       KeywordID = "haunt"
+	KeywordBuff        KeywordID = "buff"
+	KeywordArea        KeywordID = "area"
+	KeywordOrder       KeywordID = "order"
+	// Activated abilities (see activatedAbilities)
+	KeywordHoldPosition KeywordID = "hold_position"
+	KeywordAgile        KeywordID = "agile"
+	KeywordRally        KeywordID = "rally"
+	KeywordOvercharge   KeywordID = "overcharge"
+	KeywordFortify      KeywordID = "fortify"
+	KeywordSmokeBomb    KeywordID = "smoke_bomb"
+	KeywordRepair       KeywordID = "repair"
+)
+
+// KeywordParam describes whether a keyword takes a numeric parameter.
+type KeywordParam int
+
+const (
+	// KeywordParamNone keywords are written bare, e.g. "Melee".
+	KeywordParamNone KeywordParam = iota
+	// KeywordParamRequired keywords must carry a number, e.g. "Regenerate 1".
+	KeywordParamRequired
+	// KeywordParamPercent keywords carry a percentage, e.g. "Critical Hit 25%".
+	KeywordParamPercent
+)
+
+// Keyword is a parsed ability with its numeric parameter, if any.
+type Keyword struct {
+	ID    KeywordID `json:"id"`
+	Value int       `json:"value,omitempty"`
+}
+
+// KeywordHooks are the resolution-phase callbacks a keyword can implement.
+// Any hook may be nil.
+type KeywordHooks struct {
+	// OnSummon fires when the unit enters the board.
+	OnSummon func(gs *GameState, u *Unit, kw Keyword, log *EventLog)
+	// OnMove fires after each tile the unit moves.
+	OnMove func(gs *GameState, u *Unit, kw Keyword, from, to Point, log *EventLog)
+	// OnAttack fires for the attacker once its target is chosen and the
+	// defender's OnDamaged hooks have run, before damage is applied.
+	OnAttack func(gs *GameState, u *Unit, kw Keyword, hit *CombatHit, log *EventLog)
+	// OnDamaged fires for the defender before the attacker's OnAttack hooks and
+	// before combat damage is applied, so a hit it prevents is known to them.
+	OnDamaged func(gs *GameState, u *Unit, kw Keyword, hit *CombatHit, log *EventLog)
+	// OnDeath fires during death resolution after the unit has left the board.
+	OnDeath func(gs *GameState, u *Unit, kw Keyword, step string, log *EventLog)
+	// EndOfRound fires during the end-of-round step for every unit on the board.
+	EndOfRound func(gs *GameState, u *Unit, kw Keyword, log *EventLog)
+}
+
+// KeywordDefinition describes how a keyword is written on cards and what it does.
+type KeywordDefinition struct {
+	ID    KeywordID
+	Name  string
+	Param KeywordParam
+	Hooks KeywordHooks
+	// Unsupported keywords are printed on cards but have no rules in the
+	// engine yet. They parse, so the cards load, but catalogs report them.
+	Unsupported bool
+	// Descriptive keywords restate something the engine already reads from
+	// the card's data, such as its Range and Speed stats or its Aura, so they
+	// need no hooks of their own.
+	Descriptive bool
+}
+
+// keywordRegistry holds every keyword the engine accepts on a card.
+var keywordRegistry = map[KeywordID]*KeywordDefinition{}
+
+// registerKeyword adds a definition to the registry.
+func registerKeyword(def KeywordDefinition) {
+	d := def
+	keywordRegistry[def.ID] = &d
+}
+
+func init() {
+	for _, def := range []KeywordDefinition{
+		{ID: KeywordMelee, Name: "Melee", Descriptive: true},
+		{ID: KeywordRanged, Name: "Ranged", Descriptive: true},
+		{ID: KeywordFlying, Name: "Flying"},
+		{ID: KeywordHaste, Name: "Haste", Unsupported: true},
+		{ID: KeywordPhasing, Name: "Phasing"},
+		{ID: KeywordStealth, Name: "Stealth"},
+		{ID: KeywordTaunt, Name: "Taunt"},
+		{ID: KeywordProtector, Name: "Protector", Unsupported: true},
+		{ID: KeywordAura, Name: "Aura", Descriptive: true},
+		{ID: KeywordSummon, Name: "Summon"},
+		{ID: KeywordRekindle, Name: "Rekindle", Hooks: KeywordHooks{OnDeath: rekindle}},
+		{ID: KeywordDeathrattle, Name: "Deathrattle", Hooks: KeywordHooks{OnDeath: deathrattle}},
+		{ID: KeywordRegenerate, Name: "Regenerate", Param: KeywordParamRequired, Hooks: KeywordHooks{EndOfRound: regenerate}},
+		{ID: KeywordLifesteal, Name: "Lifesteal", Param: KeywordParamRequired, Hooks: KeywordHooks{OnAttack: lifesteal}},
+		{ID: KeywordArmor, Name: "Armor", Param: KeywordParamRequired, Hooks: KeywordHooks{OnSummon: armorOnSummon}},
+		{ID: KeywordPierce, Name: "Pierce", Param: KeywordParamRequired, Hooks: KeywordHooks{OnAttack: pierce}},
+		{ID: KeywordSiege, Name: "Siege", Param: KeywordParamRequired, Hooks: KeywordHooks{OnAttack: siege}},
+		{ID: KeywordSplash, Name: "Splash", Param: KeywordParamRequired, Unsupported: true},
+		{ID: KeywordSlow, Name: "Slow", Param: KeywordParamRequired, Hooks: KeywordHooks{OnAttack: slowOnHit}},
+		{ID: KeywordRoot, Name: "Root", Hooks: KeywordHooks{OnAttack: rootOnHit}},
+		{ID: KeywordEvade, Name: "Evade", Hooks: KeywordHooks{OnSummon: evadeOnSummon, OnDamaged: evade, EndOfRound: evadeEndOfRound}},
+		{ID: KeywordKnockback, Name: "Knockback", Unsupported: true},
+		{ID: KeywordCharge, Name: "Charge", Unsupported: true},
+		{ID: KeywordOverwatch, Name: "Overwatch", Unsupported: true},
+		{ID: KeywordRange, Name: "Range", Param: KeywordParamRequired, Descriptive: true},
+		{ID: KeywordSpeed, Name: "Speed", Param: KeywordParamRequired, Descriptive: true},
+		{ID: KeywordDraw, Name: "Draw", Param: KeywordParamRequired, Descriptive: true},
+		{ID: KeywordCriticalHit, Name: "Critical Hit", Param: KeywordParamPercent, Unsupported: true},
+		{ID: KeywordMultiStrike, Name: "Multi-Strike", Param: KeywordParamRequired, Unsupported: true},
+		{ID: KeywordBerserk, Name: "Berserk", Unsupported: true},
+		{ID: KeywordLucky, Name: "Lucky", Unsupported: true},
+		{ID: KeywordHaunt, Name: "Haunt", Unsupported: true},
+		{ID: KeywordBuff, Name: "Buff", Unsupported: true},
+		{ID: KeywordArea, Name: "Area", Unsupported: true},
+		{ID: KeywordOrder, Name: "Order", Unsupported: true},
+		{ID: KeywordHoldPosition, Name: "Hold Position"},
+		{ID: KeywordAgile, Name: "Agile"},
+		{ID: KeywordRally, Name: "Rally", Param: KeywordParamRequired},
+		{ID: KeywordOvercharge, Name: "Overcharge", Param: KeywordParamRequired},
+		{ID: KeywordFortify, Name: "Fortify", Param: KeywordParamRequired},
+		{ID: KeywordSmokeBomb, Name: "Smoke Bomb"},
+		{ID: KeywordRepair, Name: "Repair", Param: KeywordParamRequired},
+	} {
+		registerKeyword(def)
+	}
+}
+
+// LookupKeyword returns the registered definition for a keyword, or nil.
+func LookupKeyword(id KeywordID) *KeywordDefinition {
+	return keywordRegistry[id]
+}
+
+// ParseAbility parses an ability string such as "Regenerate 1" into a typed
+// keyword. Unknown keywords and missing or unexpected parameters are errors.
+func ParseAbility(s string) (Keyword, error) {
+	text := strings.TrimSpace(s)
+	if text == "" {
+		return Keyword{}, fmt.Errorf("empty ability")
+	}
+	// Match the longest registered name so multi-word keywords win
+	var def *KeywordDefinition
+	for _, d := range keywordRegistry {
+		if len(text) < len(d.Name) || !strings.EqualFold(text[:len(d.Name)], d.Name) {
+			continue
+		}
+		if len(text) > len(d.Name) && text[len(d.Name)] != ' ' {
+			continue
+		}
+		if def == nil || len(d.Name) > len(def.Name) {
+			def = d
+		}
+	}
+	if def == nil {
+		return Keyword{}, fmt.Errorf("unknown ability %q", s)
+	}
+
+	param := strings.TrimSpace(text[len(def.Name):])
+	switch def.Param {
+	case KeywordParamNone:
+		if param != "" {
+			return Keyword{}, fmt.Errorf("ability %q takes no parameter", s)
+		}
+		return Keyword{ID: def.ID}, nil
+	case KeywordParamPercent:
+		if !strings.HasSuffix(param, "%") {
+			return Keyword{}, fmt.Errorf("ability %q requires a percentage", s)
+		}
+		param = strings.TrimSuffix(param, "%")
+	}
+	n, err := strconv.Atoi(param)
+	if err != nil || n < 0 {
+		return Keyword{}, fmt.Errorf("ability %q requires a non-negative number", s)
+	}
+	return Keyword{ID: def.ID, Value: n}, nil
+}
+
+// ParseAbilities parses every ability string on a card, failing on the first
+// one the registry does not accept.
+func ParseAbilities(abilities []string) ([]Keyword, error) {
+	keywords := make([]Keyword, 0, len(abilities))
+	for _, a := range abilities {
+		kw, err := ParseAbility(a)
+		if err != nil {
+			return nil, err
+		}
+		keywords = append(keywords, kw)
+	}
+	return keywords, nil
+}
+
+// ValidateAbilities checks that every ability on the card is a known keyword
+// and that an Armor keyword matches the card's Armor stat, if it has one.
+// Catalogs call it when cards are loaded so typos fail loudly.
+func (c *Card) ValidateAbilities() error {
+	keywords, err := ParseAbilities(c.Abilities)
+	if err != nil {
+		return fmt.Errorf("card %s: %w", c.ID, err)
+	}
+	for _, kw := range keywords {
+		if kw.ID != KeywordArmor {
+			continue
+		}
+		if stats := c.BoardStats(); stats != nil && stats.Armor != 0 && stats.Armor != kw.Value {
+			return fmt.Errorf("card %s: Armor %d does not match its Armor stat %d", c.ID, kw.Value, stats.Armor)
+		}
+	}
+	return nil
+}
+
+// UnsupportedAbilities returns the keywords on the card that the engine has
+// no rules for. Abilities that fail to parse are left to ValidateAbilities.
+func (c *Card) UnsupportedAbilities() []KeywordID {
+	var out []KeywordID
+	for _, a := range c.Abilities {
+		kw, err := ParseAbility(a)
+		if err != nil {
+			continue
+		}
+		if def := LookupKeyword(kw.ID); def != nil && def.Unsupported {
+			out = append(out, kw.ID)
+		}
+	}
+	return out
+}
+
+// HasKeyword returns true if the unit carries the keyword.
+func (u *Unit) HasKeyword(id KeywordID) bool {
+	_, ok := u.Keyword(id)
+	return ok
+}
+
+// Keyword returns the unit's keyword with the given ID, if present.
+func (u *Unit) Keyword(id KeywordID) (Keyword, bool) {
+	for _, kw := range u.Keywords {
+		if kw.ID == id {
+			return kw, true
+		}
+	}
+	return Keyword{}, false
+}
+
+// --- Hook runners, called from the resolution steps ---
+
+// eachKeywordHook calls fn with the definition of every keyword on the unit,
+// in the order they are printed on the card.
+func eachKeywordHook(u *Unit, fn func(def *KeywordDefinition, kw Keyword)) {
+	for _, kw := range u.Keywords {
+		if def := LookupKeyword(kw.ID); def != nil {
+			fn(def, kw)
+		}
+	}
+}
+
+func runSummonHooks(gs *GameState, u *Unit, log *EventLog) {
+	eachKeywordHook(u, func(def *KeywordDefinition, kw Keyword) {
+		if def.Hooks.OnSummon != nil {
+			def.Hooks.OnSummon(gs, u, kw, log)
+		}
+	})
+}
+
+func runMoveHooks(gs *GameState, u *Unit, from, to Point, log *EventLog) {
+	eachKeywordHook(u, func(def *KeywordDefinition, kw Keyword) {
+		if def.Hooks.OnMove != nil {
+			def.Hooks.OnMove(gs, u, kw, from, to, log)
+		}
+	})
+}
+
+// runAttackHooks fires the defender's OnDamaged hooks, then the attacker's
+// OnAttack hooks, so on-hit effects can skip a hit the defender prevented.
+func runAttackHooks(gs *GameState, hit *CombatHit, log *EventLog) {
+	if hit.Target != nil {
+		eachKeywordHook(hit.Target, func(def *KeywordDefinition, kw Keyword) {
+			if def.Hooks.OnDamaged != nil {
+				def.Hooks.OnDamaged(gs, hit.Target, kw, hit, log)
+			}
+		})
+	}
+	if hit.Attacker == nil {
+		return
+	}
+	eachKeywordHook(hit.Attacker, func(def *KeywordDefinition, kw Keyword) {
+		if def.Hooks.OnAttack != nil {
+			def.Hooks.OnAttack(gs, hit.Attacker, kw, hit, log)
+		}
+	})
+}
+
+func runDeathHooks(gs *GameState, u *Unit, step string, log *EventLog) {
+	eachKeywordHook(u, func(def *KeywordDefinition, kw Keyword) {
+		if def.Hooks.OnDeath != nil {
+			def.Hooks.OnDeath(gs, u, kw, step, log)
+		}
+	})
+}
+
+// runEndOfRoundHooks fires end-of-round keyword hooks for every unit in board order.
+func runEndOfRoundHooks(gs *GameState, log *EventLog) {
+	units := append([]*Unit(nil), gs.Units...)
+	for _, u := range units {
+		if !u.IsAlive() {
+			continue
+		}
+		eachKeywordHook(u, func(def *KeywordDefinition, kw Keyword) {
+			if def.Hooks.EndOfRound != nil {
+				def.Hooks.EndOfRound(gs, u, kw, log)
+			}
+		})
+	}
+}
+
+// --- Keyword behaviours ---
+
+// regenerate heals the unit by X at the end of each round.
+func regenerate(gs *GameState, u *Unit, kw Keyword, log *EventLog) {
+	healed := min(kw.Value, u.MaxHealth-u.Health)
+	if healed <= 0 {
+		return
+	}
+	u.Health += healed
+	log.AddSimple(EventTypeTrigger, "end_of_round", map[string]any{
+		"trigger":     string(KeywordRegenerate),
+		"unitId":      u.ID,
+		"playerIndex": u.PlayerIndex,
+		"amount":      healed,
+		"health":      u.Health,
+	})
+}
+
+// lifesteal heals the attacker by X each time its attack lands.
+func lifesteal(gs *GameState, u *Unit, kw Keyword, hit *CombatHit, log *EventLog) {
+	if hit.Prevented {
+		return
+	}
+	healed := min(kw.Value, u.MaxHealth-u.Health)
+	if healed <= 0 {
+		return
+	}
+	u.Health += healed
+	log.AddSimple(EventTypeTrigger, "combat", map[string]any{
+		"trigger":     string(KeywordLifesteal),
+		"unitId":      u.ID,
+		"playerIndex": u.PlayerIndex,
+		"amount":      healed,
+		"health":      u.Health,
+	})
+}
+
+// armorOnSummon sets the unit's base Armor to X as it enters the board. Cards
+// write the Armor stat as a keyword, so it replaces the stat rather than adding
+// to it; ValidateAbilities rejects cards where the two disagree.
+func armorOnSummon(gs *GameState, u *Unit, kw Keyword, log *EventLog) {
+	u.Base.Armor = kw.Value
+	u.recomputeStats()
+}
+
+// pierce lets the attack ignore up to X Armor on the target.
+func pierce(gs *GameState, u *Unit, kw Keyword, hit *CombatHit, log *EventLog) {
+	hit.Pierce += kw.Value
+}
+
+// siege adds X damage against structures and command centers.
+func siege(gs *GameState, u *Unit, kw Keyword, hit *CombatHit, log *EventLog) {
+	if hit.CommandCenter != nil || hit.Structure != nil {
+		hit.Damage += kw.Value
+	}
+}
+
+// slowOnHit slows the unit it attacks by X until the end of next round.
+func slowOnHit(gs *GameState, u *Unit, kw Keyword, hit *CombatHit, log *EventLog) {
+	if hit.Target != nil && !hit.Prevented {
+		gs.ApplyUnitStatus(hit.Target, StatusSlow, kw.Value, string(u.ID), gs.CurrentTurn+1, "combat", log)
+	}
+}
+
+// rootOnHit roots the unit it attacks until the end of next round.
+func rootOnHit(gs *GameState, u *Unit, kw Keyword, hit *CombatHit, log *EventLog) {
+	if hit.Target != nil && !hit.Prevented {
+		gs.ApplyUnitStatus(hit.Target, StatusRoot, 0, string(u.ID), gs.CurrentTurn+1, "combat", log)
+	}
+}
+
+// evadeOnSummon gives the unit its first Evade status as it enters the board.
+func evadeOnSummon(gs *GameState, u *Unit, kw Keyword, log *EventLog) {
+	grantEvade(gs, u, "summon", log)
+}
+
+// evadeEndOfRound gives the unit a fresh Evade status if it spent its last one.
+func evadeEndOfRound(gs *GameState, u *Unit, kw Keyword, log *EventLog) {
+	grantEvade(gs, u, "end_of_round", log)
+}
+
+func grantEvade(gs *GameState, u *Unit, step string, log *EventLog) {
+	if !gs.HasStatus(string(u.ID), StatusEvade) {
+		gs.ApplyUnitStatus(u, StatusEvade, 0, string(u.ID), StatusPermanent, step, log)
+	}
+}
+
+// evade spends the unit's Evade status to make a combat hit miss.
+func evade(gs *GameState, u *Unit, kw Keyword, hit *CombatHit, log *EventLog) {
+	for _, s := range gs.Statuses {
+		if s.TargetID == string(u.ID) && s.Type == StatusEvade {
+			hit.Prevented = true
+			gs.RemoveStatus(s.ID, "consumed", "combat", log)
+			return
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseAbility(t *testing.T) {
+	cases := []struct {
+		in   string
+		want Keyword
+	}{
+		{"Melee", Keyword{ID: KeywordMelee}},
+		{"Regenerate 1", Keyword{ID: KeywordRegenerate, Value: 1}},
+		{"Deathrattle", Keyword{ID: KeywordDeathrattle}},
+		{"Lifesteal 2", Keyword{ID: KeywordLifesteal, Value: 2}},
+		{"Critical Hit 25%", Keyword{ID: KeywordCriticalHit, Value: 25}},
+		{"Ranged", Keyword{ID: KeywordRanged}},
+		{"Range 3", Keyword{ID: KeywordRange, Value: 3}},
+	}
+	for _, c := range cases {
+		got, err := ParseAbility(c.in)
+		if err != nil {
+			t.Errorf("ParseAbility(%q) returned error: %v", c.in, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ParseAbility(%q) = %+v, want %+v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestParseAbilityRejectsInvalid(t *testing.T) {
+	for _, in := range []string{"Regenrate 1", "Regenerate", "Melee 2", "Critical Hit 25", "Lifesteal x", "Deathrattle 2", ""} {
+		if kw, err := ParseAbility(in); err == nil {
+			t.Errorf("ParseAbility(%q) should fail, got %+v", in, kw)
+		}
+	}
+}
+
+func TestValidateAbilitiesReportsCard(t *testing.T) {
+	card := &Card{ID: "typo_card", Abilities: []string{"Melee", "Hsate"}}
+	if err := card.ValidateAbilities(); err == nil {
+		t.Error("Expected unknown ability to fail validation")
+	}
+}
+
+func TestUnsupportedAbilitiesAreReported(t *testing.T) {
+	card := &Card{ID: "lucky_goblin", Abilities: []string{"Melee", "Lucky", "Berserk"}}
+	if err := card.ValidateAbilities(); err != nil {
+		t.Fatalf("Expected unsupported keywords to still parse, got %v", err)
+	}
+	got := card.UnsupportedAbilities()
+	if len(got) != 2 || got[0] != KeywordLucky || got[1] != KeywordBerserk {
+		t.Errorf("Expected Lucky and Berserk to be reported, got %v", got)
+	}
+	if got := (&Card{Abilities: []string{"Melee", "Regenerate 1"}}).UnsupportedAbilities(); len(got) != 0 {
+		t.Errorf("Expected no unsupported keywords, got %v", got)
+	}
+}
+
+// TestEveryKeywordHasRules fails when a registered keyword has no hooks, is not
+// an activated ability, is not read by the engine outside keywords.go and is
+// neither Descriptive nor flagged Unsupported, so it would do nothing silently.
+func TestEveryKeywordHasRules(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	referenced := map[string]bool{}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if name == "keywords.go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok {
+				referenced[id.Name] = true
+			}
+			return true
+		})
+	}
+	// Map the registered IDs back to their constant names
+	constNames := map[KeywordID]string{}
+	f, err := parser.ParseFile(fset, "keywords.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Values) != 1 {
+			return true
+		}
+		if lit, ok := spec.Values[0].(*ast.BasicLit); ok && strings.HasPrefix(spec.Names[0].Name, "Keyword") {
+			constNames[KeywordID(strings.Trim(lit.Value, `"`))] = spec.Names[0].Name
+		}
+		return true
+	})
+
+	for id, def := range keywordRegistry {
+		h := def.Hooks
+		hooked := h.OnSummon != nil || h.OnMove != nil || h.OnAttack != nil || h.OnDamaged != nil || h.OnDeath != nil || h.EndOfRound != nil
+		_, activated := activatedAbilities[id]
+		if hooked || activated || def.Descriptive || def.Unsupported || referenced[constNames[id]] {
+			continue
+		}
+		t.Errorf("Keyword %s has no rules; implement it or mark it Unsupported", def.Name)
+	}
+}
+
+func TestArmorKeywordIsTheArmorStat(t *testing.T) {
+	gs := NewGameState("keyword-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	for _, c := range []struct {
+		stat, want int
+	}{{stat: 0, want: 2}, {stat: 2, want: 2}} {
+		card := &Card{ID: "knight", Type: CardTypeUnit, UnitStats: &UnitStats{Health: 3, Armor: c.stat}, Abilities: []string{"Armor 2"}}
+		if err := card.ValidateAbilities(); err != nil {
+			t.Fatalf("stat=%d: expected the card to validate, got %v", c.stat, err)
+		}
+		u := gs.SpawnUnit(0, NewCardInstance(card.ID), card, Point{Row: 8, Col: c.stat})
+		runSummonHooks(gs, u, NewEventLog(gs.CurrentTurn))
+		if u.Armor != c.want {
+			t.Errorf("stat=%d: expected Armor %d, got %d", c.stat, c.want, u.Armor)
+		}
+	}
+
+	mismatch := &Card{ID: "knight", Type: CardTypeUnit, UnitStats: &UnitStats{Health: 3, Armor: 1}, Abilities: []string{"Armor 2"}}
+	if err := mismatch.ValidateAbilities(); err == nil {
+		t.Error("Expected an Armor keyword that disagrees with the stat to fail validation")
+	}
+}
+
+func TestRegenerateHealsAtEndOfRound(t *testing.T) {
+	gs := NewGameState("keyword-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	u := placeTestUnit(gs, 0, Point{Row: 6, Col: 2}, UnitStats{Attack: 1, Health: 4, Range: 1})
+	u.Keywords = []Keyword{{ID: KeywordRegenerate, Value: 1}}
+	u.Health = 2
+
+	log := NewEventLog(gs.CurrentTurn)
+	runEndOfRoundHooks(gs, log)
+	if u.Health != 3 {
+		t.Errorf("Expected Regenerate 1 to heal to 3, got %d", u.Health)
+	}
+	runEndOfRoundHooks(gs, log)
+	runEndOfRoundHooks(gs, log)
+	if u.Health != u.MaxHealth {
+		t.Errorf("Regenerate should not heal past max health, got %d", u.Health)
+	}
+}
@@ -0,0 +1,276 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// MapID uniquely identifies a map definition.
+type MapID string
+
+// MapDefinition is a data-driven board layout: the board size, where each
+// player's command center and starter buildings stand, which players play
+// together, and any tiles whose zone differs from the default layout. The map
+// seats one player per command center.
+type MapDefinition struct {
+	ID          MapID          `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Rows        int            `json:"rows"`
+	Cols        int            `json:"cols"`
+	Structures  []MapStructure `json:"structures"`
+	Zones       []ZoneOverride `json:"zones,omitempty"`
+	// Teams is each player's team, by player index; without it every player
+	// plays for themselves
+	Teams []int `json:"teams,omitempty"`
+	// Terrain lays out the tiles' ground; a map without it is all grass
+	Terrain *MapTerrain `json:"terrain,omitempty"`
+}
+
+// MapStructure places one structure on a map. Row and Col are its top-left
+// tile. Type is a command center, Tower or Barracks; each player needs exactly
+// one command center.
+type MapStructure struct {
+	PlayerIndex int          `json:"playerIndex"`
+	Type        BuildingType `json:"type"`
+	Row         int          `json:"row"`
+	Col         int          `json:"col"`
+	Footprint   Footprint    `json:"footprint"`
+}
+
+// footprint returns the structure's footprint. A command center without one
+// gets DefaultCommandCenterFootprint, like in the default layout.
+func (s MapStructure) footprint() Footprint {
+	if s.Type == BuildingCommandCenter && s.Footprint == (Footprint{}) {
+		return DefaultCommandCenterFootprint
+	}
+	return s.Footprint
+}
+
+// ZoneOverride replaces the default zone of a rectangle of tiles. Zone is read
+// from the point of view of each player in Players, or of every player if
+// Players is empty. Deployment defaults to whether Zone is the player's base.
+type ZoneOverride struct {
+	Players    []int     `json:"players,omitempty"`
+	Row        int       `json:"row"`
+	Col        int       `json:"col"`
+	Footprint  Footprint `json:"footprint"`
+	Zone       Zone      `json:"zone"`
+	Deployment *bool     `json:"deployment,omitempty"`
+}
+
+// Clone returns a deep copy of the map definition, so a caller can change it
+// without affecting the original.
+func (m *MapDefinition) Clone() *MapDefinition {
+	c := *m
+	c.Structures = append([]MapStructure(nil), m.Structures...)
+	c.Zones = nil
+	for _, z := range m.Zones {
+		z.Players = append([]int(nil), z.Players...)
+		if z.Deployment != nil {
+			deployment := *z.Deployment
+			z.Deployment = &deployment
+		}
+		c.Zones = append(c.Zones, z)
+	}
+	c.Teams = append([]int(nil), m.Teams...)
+	if m.Terrain != nil {
+		terrain := *m.Terrain
+		terrain.Tiles = append([]TerrainOverride(nil), m.Terrain.Tiles...)
+		c.Terrain = &terrain
+	}
+	return &c
+}
+
+// MapRepository defines the interface for map definition access.
+type MapRepository interface {
+	// GetMap retrieves a map definition by its ID.
+	GetMap(ctx context.Context, id MapID) (*MapDefinition, error)
+
+	// GetAllMaps retrieves all map definitions.
+	GetAllMaps(ctx context.Context) ([]*MapDefinition, error)
+}
+
+// ParseMapDefinition decodes a JSON map definition and validates it.
+func ParseMapDefinition(data []byte) (*MapDefinition, error) {
+	var m MapDefinition
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("decode map: %w", err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Validate checks that the map is playable: a positive board size, every
+// structure and zone override on the board, known structure types and zones,
+// one command center for each of at least two players, and if teams are given,
+// one per player with at least two different teams.
+func (m *MapDefinition) Validate() error {
+	if m.ID == "" {
+		return fmt.Errorf("map has no id")
+	}
+	if m.Rows <= 0 || m.Cols <= 0 {
+		return fmt.Errorf("map %s: invalid board size %dx%d", m.ID, m.Rows, m.Cols)
+	}
+	inBounds := func(topLeft Point, f Footprint) bool {
+		w, h := f.size()
+		return topLeft.Row >= 0 && topLeft.Col >= 0 && topLeft.Row+h <= m.Rows && topLeft.Col+w <= m.Cols
+	}
+	commandCenters := map[int]bool{}
+	for i, s := range m.Structures {
+		if !inBounds(Point{Row: s.Row, Col: s.Col}, s.footprint()) {
+			return fmt.Errorf("map %s: structure %d is off the board", m.ID, i)
+		}
+		switch s.Type {
+		case BuildingCommandCenter:
+			if commandCenters[s.PlayerIndex] {
+				return fmt.Errorf("map %s: player %d has more than one command center", m.ID, s.PlayerIndex)
+			}
+			commandCenters[s.PlayerIndex] = true
+		case BuildingTower, BuildingBarracks:
+		default:
+			return fmt.Errorf("map %s: structure %d has unknown type %q", m.ID, i, s.Type)
+		}
+	}
+	if len(commandCenters) < 2 {
+		return fmt.Errorf("map %s: needs a command center for at least two players", m.ID)
+	}
+	for i := 0; i < len(commandCenters); i++ {
+		if !commandCenters[i] {
+			return fmt.Errorf("map %s: player %d has no command center", m.ID, i)
+		}
+	}
+	if len(m.Teams) > 0 {
+		if len(m.Teams) != len(commandCenters) {
+			return fmt.Errorf("map %s: has %d teams for %d players", m.ID, len(m.Teams), len(commandCenters))
+		}
+		distinct := map[int]bool{}
+		for i, team := range m.Teams {
+			if team < 0 {
+				return fmt.Errorf("map %s: player %d has invalid team %d", m.ID, i, team)
+			}
+			distinct[team] = true
+		}
+		if len(distinct) < 2 {
+			return fmt.Errorf("map %s: needs at least two teams", m.ID)
+		}
+	}
+	for i, z := range m.Zones {
+		if !inBounds(Point{Row: z.Row, Col: z.Col}, z.Footprint) {
+			return fmt.Errorf("map %s: zone override %d is off the board", m.ID, i)
+		}
+		switch z.Zone {
+		case ZoneBase, ZoneEnemyBase, ZoneSideBuffer, ZoneNeutral:
+		default:
+			return fmt.Errorf("map %s: zone override %d has unknown zone %q", m.ID, i, z.Zone)
+		}
+	}
+	if m.Terrain != nil {
+		if m.Terrain.Fill != "" && !knownTerrain(m.Terrain.Fill) {
+			return fmt.Errorf("map %s: unknown terrain fill %q", m.ID, m.Terrain.Fill)
+		}
+		for i, t := range m.Terrain.Tiles {
+			if !inBounds(Point{Row: t.Row, Col: t.Col}, t.Footprint) {
+				return fmt.Errorf("map %s: terrain tile %d is off the board", m.ID, i)
+			}
+			if !knownTerrain(t.Terrain) {
+				return fmt.Errorf("map %s: terrain tile %d has unknown terrain %q", m.ID, i, t.Terrain)
+			}
+		}
+	}
+	return nil
+}
+
+// PlayerCount returns how many players the map seats: one per command center.
+func (m *MapDefinition) PlayerCount() int {
+	n := 0
+	for _, s := range m.Structures {
+		if s.Type == BuildingCommandCenter {
+			n++
+		}
+	}
+	return n
+}
+
+// NewGameStateFromMap creates a new game state laid out from a map
+// definition, with the map's teams. Starter buildings go where the map puts
+// them; a blocked single-tile one falls back to the nearest free base tile like
+// the default layout. A map that lists no starter buildings gets the default ones.
+// The match is seeded up front, since generated terrain follows the seed and
+// structures are placed against it.
+func NewGameStateFromMap(gameID GameID, players []Player, m *MapDefinition, seed int64) *GameState {
+	var commandCenters []*CommandCenter
+	for _, s := range m.Structures {
+		if s.Type != BuildingCommandCenter {
+			continue
+		}
+		cc := NewCommandCenter(s.PlayerIndex, s.Row, s.Col)
+		cc.Footprint = s.footprint()
+		commandCenters = append(commandCenters, cc)
+	}
+	var teams []int
+	if len(m.Teams) > 0 {
+		teams = append([]int{}, m.Teams...)
+	}
+	gs := newGameState(gameID, players, m.Rows, m.Cols, commandCenters, teams, seed, m.Terrain)
+	gs.MapID = m.ID
+	gs.applyZoneOverrides(m.Zones)
+
+	starters := 0
+	for _, s := range m.Structures {
+		if s.Type == BuildingCommandCenter {
+			continue
+		}
+		starters++
+		stats := starterStructureStats[s.Type]
+		pos := Point{Row: s.Row, Col: s.Col}
+		if gs.addStructureWithFootprint(s.PlayerIndex, s.Type, stats, pos, s.Footprint) != nil {
+			continue
+		}
+		if w, h := s.Footprint.size(); w == 1 && h == 1 {
+			if pos, ok := gs.nearestFreeBaseTile(s.PlayerIndex, pos); ok {
+				gs.AddStructure(s.PlayerIndex, s.Type, stats, pos)
+			}
+		}
+	}
+	if starters == 0 {
+		gs.placeStarterStructures()
+	}
+	return gs
+}
+
+// applyZoneOverrides rewrites the zone maps for the tiles the overrides cover,
+// in order, so later overrides win.
+func (gs *GameState) applyZoneOverrides(overrides []ZoneOverride) {
+	for _, z := range overrides {
+		deploy := z.Zone == ZoneBase
+		if z.Deployment != nil {
+			deploy = *z.Deployment
+		}
+		for i := range gs.Zones {
+			pz := &gs.Zones[i]
+			if len(z.Players) > 0 && !containsInt(z.Players, pz.PlayerIndex) {
+				continue
+			}
+			for _, t := range z.Footprint.tiles(Point{Row: z.Row, Col: z.Col}) {
+				if !gs.InBounds(t.Row, t.Col) {
+					continue
+				}
+				pz.Tiles[t.Row][t.Col] = z.Zone
+				pz.Deployment[t.Row][t.Col] = deploy
+			}
+		}
+	}
+}
+
+func containsInt(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
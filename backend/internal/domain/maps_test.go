@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"testing"
+)
+
+const classicMapJSON = `{
+  "id": "classic_11x13",
+  "name": "Classic",
+  "rows": 13,
+  "cols": 11,
+  "structures": [
+    {"playerIndex": 0, "type": "command_center", "row": 12, "col": 5, "footprint": {"width": 1, "height": 1}},
+    {"playerIndex": 0, "type": "tower", "row": 12, "col": 3},
+    {"playerIndex": 0, "type": "barracks", "row": 12, "col": 7},
+    {"playerIndex": 1, "type": "command_center", "row": 0, "col": 4, "footprint": {"width": 3, "height": 2}},
+    {"playerIndex": 1, "type": "tower", "row": 0, "col": 2}
+  ],
+  "zones": [
+    {"players": [0], "row": 6, "col": 0, "footprint": {"width": 2, "height": 1}, "zone": "base"},
+    {"row": 6, "col": 10, "zone": "neutral", "deployment": true}
+  ]
+}`
+
+func TestNewGameStateFromMapPlacesStructuresAndZones(t *testing.T) {
+	m, err := ParseMapDefinition([]byte(classicMapJSON))
+	if err != nil {
+		t.Fatalf("Expected the map to parse, got %v", err)
+	}
+	gs := NewGameStateFromMap("map-test", []Player{{ID: "p1"}, {ID: "p2"}}, m, 1)
+
+	if gs.BoardRows != 13 || gs.BoardCols != 11 || gs.MapID != "classic_11x13" {
+		t.Fatalf("Expected a 13x11 board from the map, got %dx%d map=%q", gs.BoardRows, gs.BoardCols, gs.MapID)
+	}
+
+	// Player 0's command center covers a single tile
+	if !gs.IsTileOccupied(12, 5) || gs.IsTileOccupied(11, 5) || gs.IsTileOccupied(12, 6) {
+		t.Error("Expected player 0's command center to cover only its own tile")
+	}
+	// Player 1's command center covers 3x2 tiles
+	for _, p := range []Point{{0, 4}, {0, 6}, {1, 4}, {1, 6}} {
+		if !gs.IsTileOccupied(p.Row, p.Col) {
+			t.Errorf("Expected player 1's command center to cover %v", p)
+		}
+	}
+	if gs.IsTileOccupied(2, 5) || gs.IsTileOccupied(0, 7) {
+		t.Error("Expected tiles outside the 3x2 footprint to be free")
+	}
+	if d := distanceToCommandCenter(Point{Row: 4, Col: 8}, gs.GetCommandCenter(1)); d != 5 {
+		t.Errorf("Expected distance 5 to the closest footprint tile, got %d", d)
+	}
+
+	if st := gs.StructureAt(12, 3); st == nil || st.Building.Type != BuildingTower || st.PlayerIndex != 0 {
+		t.Errorf("Expected player 0's Tower at the map position, got %+v", st)
+	}
+	if st := gs.StructureAt(12, 7); st == nil || st.Building.Type != BuildingBarracks {
+		t.Errorf("Expected player 0's Barracks at the map position, got %+v", st)
+	}
+	if got := len(gs.PlayerStructures(1)); got != 1 {
+		t.Errorf("Expected only the starter buildings the map lists, got %d for player 1", got)
+	}
+
+	// Zone overrides apply to the listed players only, or to everyone
+	if gs.ZoneAt(0, 6, 1) != ZoneBase || !gs.InDeploymentZone(0, 6, 1) {
+		t.Error("Expected the override to give player 0 a forward base tile")
+	}
+	if gs.ZoneAt(1, 6, 1) != ZoneNeutral || gs.InDeploymentZone(1, 6, 1) {
+		t.Error("Expected player 1's view of the tile to be unchanged")
+	}
+	for _, pi := range []int{0, 1} {
+		if gs.ZoneAt(pi, 6, 10) != ZoneNeutral || !gs.InDeploymentZone(pi, 6, 10) {
+			t.Errorf("Expected player %d to be able to deploy on the neutral override tile", pi)
+		}
+	}
+}
+
+func TestMultiTileStructureFootprint(t *testing.T) {
+	gs := NewGameState("map-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	st := gs.addStructureWithFootprint(0, BuildingCardStructure, BuildingStats{Health: 10}, Point{Row: 5, Col: 5}, Footprint{Width: 2, Height: 2})
+	if st == nil {
+		t.Fatal("Expected the 2x2 structure to be placed")
+	}
+	if gs.StructureAt(6, 6) != st || gs.StructureAt(7, 6) != nil {
+		t.Error("Expected StructureAt to follow the footprint")
+	}
+	if gs.addStructureWithFootprint(1, BuildingCardStructure, BuildingStats{Health: 10}, Point{Row: 6, Col: 4}, Footprint{Width: 2, Height: 1}) != nil {
+		t.Error("Expected an overlapping footprint to be rejected")
+	}
+	if st.distanceTo(Point{Row: 6, Col: 8}) != 2 {
+		t.Errorf("Expected distance 2 to the closest footprint tile, got %d", st.distanceTo(Point{Row: 6, Col: 8}))
+	}
+}
+
+func TestDefaultCommandCentersFollowBoardSize(t *testing.T) {
+	gs := NewGameState("map-test", []Player{{ID: "p1"}, {ID: "p2"}}, 13, 11)
+	bottom, top := gs.GetCommandCenter(0), gs.GetCommandCenter(1)
+	if bottom.TopLeftRow != 11 || bottom.TopLeftCol != 4 {
+		t.Errorf("Expected player 0's command center on the bottom rows, got (%d,%d)", bottom.TopLeftRow, bottom.TopLeftCol)
+	}
+	if top.TopLeftRow != 0 || top.TopLeftCol != 4 {
+		t.Errorf("Expected player 1's command center on the top rows, got (%d,%d)", top.TopLeftRow, top.TopLeftCol)
+	}
+}
+
+func TestMapCommandCenterWithoutFootprintUsesDefault(t *testing.T) {
+	m, err := ParseMapDefinition([]byte(`{
+  "id": "no_footprint",
+  "rows": 12,
+  "cols": 12,
+  "structures": [
+    {"playerIndex": 0, "type": "command_center", "row": 10, "col": 5},
+    {"playerIndex": 1, "type": "command_center", "row": 0, "col": 5}
+  ]
+}`))
+	if err != nil {
+		t.Fatalf("Expected the map to parse, got %v", err)
+	}
+	gs := NewGameStateFromMap("map-test", []Player{{ID: "p1"}, {ID: "p2"}}, m, 1)
+	for i := 0; i < 2; i++ {
+		if cc := gs.GetCommandCenter(i); cc.Footprint != DefaultCommandCenterFootprint {
+			t.Errorf("Expected player %d's command center to use the default footprint, got %+v", i, cc.Footprint)
+		}
+	}
+	if !gs.IsTileOccupied(11, 6) || !gs.IsTileOccupied(1, 6) {
+		t.Error("Expected the command centers to cover their full default footprint")
+	}
+
+	m.Structures[0].Row = 11
+	if err := m.Validate(); err == nil {
+		t.Error("Expected a default-size command center hanging off the board to fail validation")
+	}
+}
+
+func TestMapDefinitionCloneSharesNothing(t *testing.T) {
+	deployment := true
+	m := &MapDefinition{
+		ID:         "clone",
+		Structures: []MapStructure{{PlayerIndex: 0, Type: BuildingCommandCenter}},
+		Zones:      []ZoneOverride{{Players: []int{0}, Zone: ZoneBase, Deployment: &deployment}},
+		Teams:      []int{0, 1},
+		Terrain:    &MapTerrain{Tiles: []TerrainOverride{{Terrain: TerrainWater}}},
+	}
+	c := m.Clone()
+	c.Structures[0].Row = 5
+	c.Zones[0].Players[0] = 1
+	*c.Zones[0].Deployment = false
+	c.Teams[0] = 1
+	c.Terrain.Fill = TerrainWater
+	c.Terrain.Tiles[0].Row = 3
+
+	if m.Structures[0].Row != 0 || m.Zones[0].Players[0] != 0 || !*m.Zones[0].Deployment || m.Teams[0] != 0 {
+		t.Errorf("Expected the original map to be unchanged, got %+v", m)
+	}
+	if m.Terrain.Fill != "" || m.Terrain.Tiles[0].Row != 0 {
+		t.Errorf("Expected the original terrain to be unchanged, got %+v", m.Terrain)
+	}
+}
+
+func TestParseMapDefinitionRejectsInvalidMaps(t *testing.T) {
+	for name, data := range map[string]string{
+		"missing command center": `{"id":"m","rows":5,"cols":5,"structures":[{"playerIndex":0,"type":"command_center","row":4,"col":2}]}`,
+		"off the board":          `{"id":"m","rows":5,"cols":5,"structures":[{"playerIndex":0,"type":"command_center","row":4,"col":4,"footprint":{"width":2,"height":1}},{"playerIndex":1,"type":"command_center","row":0,"col":2}]}`,
+		"unknown zone":           `{"id":"m","rows":5,"cols":5,"structures":[{"playerIndex":0,"type":"command_center","row":4,"col":2},{"playerIndex":1,"type":"command_center","row":0,"col":2}],"zones":[{"row":2,"col":2,"zone":"lava"}]}`,
+		"unknown terrain":        `{"id":"m","rows":5,"cols":5,"structures":[{"playerIndex":0,"type":"command_center","row":4,"col":2},{"playerIndex":1,"type":"command_center","row":0,"col":2}],"terrain":{"tiles":[{"row":2,"col":2,"terrain":"lava"}]}}`,
+		"bad json":               `{"id":`,
+	} {
+		if _, err := ParseMapDefinition([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
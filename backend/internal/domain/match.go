@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"time"
+)
+
+// MatchOutcome is how a finished match turned out.
+type MatchOutcome string
+
+const (
+	MatchOutcomeWin  MatchOutcome = "win"
+	MatchOutcomeDraw MatchOutcome = "draw"
+)
+
+// MatchEndReason is why a match ended.
+type MatchEndReason string
+
+const (
+	// MatchEndCommandCenters is every opposing command center destroyed, or
+	// all of them at once for a draw
+	MatchEndCommandCenters MatchEndReason = "command_center_destroyed"
+	// MatchEndConcede is every opposing player conceding
+	MatchEndConcede MatchEndReason = "concede"
+	// MatchEndTurnLimit is the turn limit running out; the team with the most
+	// command center HP left wins
+	MatchEndTurnLimit MatchEndReason = "turn_limit"
+)
+
+// MatchResult is the final result of a match.
+type MatchResult struct {
+	Outcome MatchOutcome   `json:"outcome"`
+	Reason  MatchEndReason `json:"reason"`
+	// WinningTeam is -1 on a draw
+	WinningTeam int   `json:"winningTeam"`
+	Winners     []int `json:"winners"`
+	// Turn is the turn the match ended on
+	Turn int `json:"turn"`
+	// CommandCenterHealth is each team's total command center HP at the end
+	CommandCenterHealth map[int]int `json:"commandCenterHealth"`
+}
+
+// Concede forfeits the match for a player. Their command center no longer
+// counts for their team, and if that leaves a single team the match ends.
+// Returns the result if the match ended.
+func (gs *GameState) Concede(playerIndex int) *MatchResult {
+	if gs.Result != nil || playerIndex < 0 || playerIndex >= gs.PlayerCount() {
+		return gs.Result
+	}
+	if !gs.HasConceded(playerIndex) {
+		gs.Conceded = append(gs.Conceded, playerIndex)
+		gs.UpdatedAt = time.Now()
+	}
+	if alive := gs.teamsAlive(); len(alive) <= 1 {
+		return gs.endMatch(MatchEndConcede, alive)
+	}
+	return nil
+}
+
+// HasConceded reports whether a player has conceded.
+func (gs *GameState) HasConceded(playerIndex int) bool {
+	return containsInt(gs.Conceded, playerIndex)
+}
+
+// CheckMatchEnd ends the match once at most one team still has a standing
+// command center: that team wins, or it is a draw if the last command centers
+// fell together. It is checked after every step that can destroy a command
+// center, never in the middle of one, so simultaneous destruction is a draw.
+// Returns the result if the match is over.
+func (gs *GameState) CheckMatchEnd() *MatchResult {
+	if gs.Result != nil {
+		return gs.Result
+	}
+	eliminated := false
+	for i := 0; i < gs.PlayerCount(); i++ {
+		if gs.IsEliminated(i) {
+			eliminated = true
+			break
+		}
+	}
+	if !eliminated {
+		return nil
+	}
+	if alive := gs.teamsAlive(); len(alive) <= 1 {
+		return gs.endMatch(MatchEndCommandCenters, alive)
+	}
+	return nil
+}
+
+// checkTurnLimit ends the match once its final round has resolved. The team
+// with the most command center HP left wins; a tie is a draw.
+func (gs *GameState) checkTurnLimit() *MatchResult {
+	if gs.Result != nil || gs.TurnLimit <= 0 || gs.CurrentTurn+1 < gs.TurnLimit {
+		return gs.Result
+	}
+	health := gs.teamCommandCenterHealth()
+	var leaders []int
+	best := 0
+	for _, team := range gs.teamsAlive() {
+		switch hp := health[team]; {
+		case len(leaders) == 0 || hp > best:
+			leaders = []int{team}
+			best = hp
+		case hp == best:
+			leaders = append(leaders, team)
+		}
+	}
+	return gs.endMatch(MatchEndTurnLimit, leaders)
+}
+
+// teamCommandCenterHealth totals each team's standing command center HP.
+func (gs *GameState) teamCommandCenterHealth() map[int]int {
+	health := map[int]int{}
+	for _, cc := range gs.CommandCenters {
+		hp := cc.Health
+		if gs.HasConceded(cc.PlayerIndex) {
+			hp = 0
+		}
+		health[gs.TeamOf(cc.PlayerIndex)] += hp
+	}
+	return health
+}
+
+// endMatch finishes the match. A single team in contenders wins; none or
+// several make it a draw.
+func (gs *GameState) endMatch(reason MatchEndReason, contenders []int) *MatchResult {
+	result := &MatchResult{
+		Outcome:             MatchOutcomeDraw,
+		Reason:              reason,
+		WinningTeam:         -1,
+		Turn:                gs.CurrentTurn,
+		CommandCenterHealth: gs.teamCommandCenterHealth(),
+	}
+	if len(contenders) == 1 {
+		result.Outcome = MatchOutcomeWin
+		result.WinningTeam = contenders[0]
+		for i := 0; i < gs.PlayerCount(); i++ {
+			if gs.TeamOf(i) == result.WinningTeam {
+				result.Winners = append(result.Winners, i)
+			}
+		}
+	}
+	gs.Result = result
+	gs.Status = GameStatusFinished
+	gs.UpdatedAt = time.Now()
+	return result
+}
+
+// matchEndData is the event data logged when a match ends.
+func matchEndData(result *MatchResult) map[string]any {
+	winner := -1
+	if len(result.Winners) > 0 {
+		winner = result.Winners[0]
+	}
+	return map[string]any{
+		"winner":      winner,
+		"winningTeam": result.WinningTeam,
+		"winners":     result.Winners,
+		"outcome":     string(result.Outcome),
+		"reason":      string(result.Reason),
+	}
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestSimultaneousCommandCenterDestructionIsDraw(t *testing.T) {
+	gs := NewGameState("match-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0}, {PlayerIndex: 1}}
+	gs.StartGame()
+	for _, cc := range gs.CommandCenters {
+		cc.Health = 1
+	}
+	// Each unit stands next to the enemy command center
+	placeTestUnit(gs, 0, Point{Row: 2, Col: 5}, UnitStats{Attack: 5, Health: 5, Range: 1})
+	placeTestUnit(gs, 1, Point{Row: 9, Col: 5}, UnitStats{Attack: 5, Health: 5, Range: 1})
+
+	log := ExecuteResolutionPhase(gs)
+
+	if gs.Result == nil || gs.Status != GameStatusFinished {
+		t.Fatal("Expected the match to end when both command centers fall")
+	}
+	if gs.Result.Outcome != MatchOutcomeDraw || gs.Result.WinningTeam != -1 || len(gs.Result.Winners) != 0 {
+		t.Errorf("Expected a draw, got %+v", gs.Result)
+	}
+	if gs.GetWinner() != -1 {
+		t.Errorf("Expected no winner on a draw, got %d", gs.GetWinner())
+	}
+	found := false
+	for _, e := range log.Events {
+		if e.Type == EventTypeMatchEnd && e.Data["outcome"] == string(MatchOutcomeDraw) && e.Data["reason"] == string(MatchEndCommandCenters) {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected a match end event recording the draw")
+	}
+}
+
+func TestConcedeEndsMatch(t *testing.T) {
+	gs := NewGameState("match-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.StartGame()
+
+	result := gs.Concede(1)
+	if result == nil || gs.Status != GameStatusFinished {
+		t.Fatal("Expected conceding to end a two-player match")
+	}
+	if result.Outcome != MatchOutcomeWin || result.Reason != MatchEndConcede || gs.GetWinner() != 0 {
+		t.Errorf("Expected player 0 to win by concession, got %+v", result)
+	}
+	// A finished match keeps its result
+	if again := gs.Concede(0); again != result {
+		t.Errorf("Expected the first result to stand, got %+v", again)
+	}
+}
+
+func TestConcedeInFreeForAllLeavesOthersPlaying(t *testing.T) {
+	gs := NewGameState("match-test", fourPlayers(), 12, 16)
+	gs.StartGame()
+
+	if result := gs.Concede(2); result != nil {
+		t.Fatalf("Expected three players to play on, got %+v", result)
+	}
+	if !gs.IsEliminated(2) {
+		t.Error("Expected a conceded player to be out")
+	}
+	for _, i := range []int{0, 1, 3} {
+		gs.LockPlayerChoice(i)
+	}
+	if !gs.AreAllPlayersLocked() {
+		t.Error("Expected Planning not to wait on a conceded player")
+	}
+	gs.Concede(1)
+	if result := gs.Concede(3); result == nil || result.Winners[0] != 0 {
+		t.Errorf("Expected player 0 to win once everyone else conceded, got %+v", result)
+	}
+}
+
+func TestTurnLimitDecidedOnCommandCenterHealth(t *testing.T) {
+	gs := NewGameState("match-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0}, {PlayerIndex: 1}}
+	gs.StartGame()
+	gs.TurnLimit = 3
+	gs.GetCommandCenter(0).Health = 60
+
+	gs.CurrentTurn = 1
+	ExecuteResolutionPhase(gs)
+	if gs.Result != nil {
+		t.Fatalf("Expected the match to go on before its last round, got %+v", gs.Result)
+	}
+
+	gs.CurrentTurn = 2
+	ExecuteResolutionPhase(gs)
+	if gs.Result == nil || gs.Result.Reason != MatchEndTurnLimit {
+		t.Fatalf("Expected the turn limit to end the match, got %+v", gs.Result)
+	}
+	if gs.Result.Outcome != MatchOutcomeWin || gs.GetWinner() != 1 {
+		t.Errorf("Expected player 1 to win on command center HP, got %+v", gs.Result)
+	}
+	if gs.Result.CommandCenterHealth[0] != 60 || gs.Result.CommandCenterHealth[1] != 100 {
+		t.Errorf("Expected the final HP in the result, got %v", gs.Result.CommandCenterHealth)
+	}
+}
+
+func TestTurnLimitTieIsDraw(t *testing.T) {
+	gs := NewGameState("match-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0}, {PlayerIndex: 1}}
+	gs.StartGame()
+	gs.TurnLimit = 1
+
+	ExecuteResolutionPhase(gs)
+	if gs.Result == nil || gs.Result.Outcome != MatchOutcomeDraw || gs.Result.Reason != MatchEndTurnLimit {
+		t.Errorf("Expected equal command centers at the turn limit to draw, got %+v", gs.Result)
+	}
+}
@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// ModifierLayer groups stat modifiers. Layers are applied in a fixed order on
+// top of an entity's base stats.
+type ModifierLayer string
+
+const (
+	// ModifierEquipment holds permanent changes: upgrades, permanent buffs and
+	// stats granted by keywords.
+	ModifierEquipment ModifierLayer = "equipment"
+	// ModifierStatus holds the stat changes of active statuses.
+	ModifierStatus ModifierLayer = "status"
+	// ModifierAura holds bonuses granted by nearby units' auras. They are
+	// rebuilt whenever units enter, move or leave.
+	ModifierAura ModifierLayer = "aura"
+	// ModifierTerrain holds bonuses from the tile a unit stands on. They are
+	// rebuilt together with auras.
+	ModifierTerrain ModifierLayer = "terrain"
+)
+
+// modifierLayerOrder is the order layers are applied in.
+var modifierLayerOrder = map[ModifierLayer]int{
+	ModifierEquipment: 0,
+	ModifierStatus:    1,
+	ModifierAura:      2,
+	ModifierTerrain:   3,
+}
+
+// StatModifier is one entry in an entity's modifier stack.
+type StatModifier struct {
+	Layer    ModifierLayer `json:"layer"`
+	SourceID string        `json:"sourceId"`
+	Attack   int           `json:"attack,omitempty"`
+	Health   int           `json:"health,omitempty"`
+	Armor    int           `json:"armor,omitempty"`
+	Speed    int           `json:"speed,omitempty"`
+	Range    int           `json:"range,omitempty"`
+}
+
+// isZero reports whether the modifier changes nothing.
+func (m StatModifier) isZero() bool {
+	return m.Attack == 0 && m.Health == 0 && m.Armor == 0 && m.Speed == 0 && m.Range == 0
+}
+
+// applyModifiers returns base with every modifier in the stack applied in
+// order. Attack, Armor, Speed and Range never go below zero and Health never
+// below one.
+func applyModifiers(base UnitStats, mods []StatModifier) UnitStats {
+	s := base
+	for _, m := range mods {
+		s.Attack += m.Attack
+		s.Health += m.Health
+		s.Armor += m.Armor
+		s.Speed += m.Speed
+		s.Range += m.Range
+	}
+	s.Attack = max(0, s.Attack)
+	s.Health = max(1, s.Health)
+	s.Armor = max(0, s.Armor)
+	s.Speed = max(0, s.Speed)
+	s.Range = max(0, s.Range)
+	return s
+}
+
+// sortModifiers keeps a stack in layer order, preserving the order entries
+// were added within a layer.
+func sortModifiers(mods []StatModifier) {
+	sort.SliceStable(mods, func(i, j int) bool {
+		return modifierLayerOrder[mods[i].Layer] < modifierLayerOrder[mods[j].Layer]
+	})
+}
+
+// withoutModifiers returns mods minus the entries of the given layer, and of
+// the given source if sourceID is not empty.
+func withoutModifiers(mods []StatModifier, layer ModifierLayer, sourceID string) []StatModifier {
+	kept := mods[:0]
+	for _, m := range mods {
+		if m.Layer == layer && (sourceID == "" || m.SourceID == sourceID) {
+			continue
+		}
+		kept = append(kept, m)
+	}
+	return kept
+}
+
+// healthAfterMaxChange adjusts current health when max health moves: gains
+// in max health heal by the same amount, losses only cap health and never
+// kill a living entity.
+func healthAfterMaxChange(health, oldMax, newMax int) int {
+	if newMax > oldMax {
+		return health + newMax - oldMax
+	}
+	if health <= 0 {
+		return health
+	}
+	return max(1, min(health, newMax))
+}
+
+// AddModifier pushes a modifier onto the unit's stack and recomputes its stats.
+func (u *Unit) AddModifier(m StatModifier) {
+	if m.isZero() {
+		return
+	}
+	u.Modifiers = append(u.Modifiers, m)
+	u.recomputeStats()
+}
+
+// RemoveModifiers drops the unit's modifiers from a layer (and source, if
+// given) and recomputes its stats.
+func (u *Unit) RemoveModifiers(layer ModifierLayer, sourceID string) {
+	u.Modifiers = withoutModifiers(u.Modifiers, layer, sourceID)
+	u.recomputeStats()
+}
+
+// recomputeStats sets the unit's effective stats from its base stats and
+// modifier stack.
+func (u *Unit) recomputeStats() {
+	sortModifiers(u.Modifiers)
+	s := applyModifiers(u.Base, u.Modifiers)
+	u.Health = healthAfterMaxChange(u.Health, u.MaxHealth, s.Health)
+	u.Attack, u.MaxHealth, u.Armor, u.Speed, u.Range = s.Attack, s.Health, s.Armor, s.Speed, s.Range
+}
+
+// AddModifier pushes a modifier onto the structure's stack and recomputes its stats.
+func (st *Structure) AddModifier(m StatModifier) {
+	if m.isZero() {
+		return
+	}
+	st.Modifiers = append(st.Modifiers, m)
+	st.recomputeStats()
+}
+
+// RemoveModifiers drops the structure's modifiers from a layer (and source,
+// if given) and recomputes its stats.
+func (st *Structure) RemoveModifiers(layer ModifierLayer, sourceID string) {
+	st.Modifiers = withoutModifiers(st.Modifiers, layer, sourceID)
+	st.recomputeStats()
+}
+
+// recomputeStats sets the structure's effective stats from its base stats
+// and modifier stack. Structures never move, so Speed is ignored.
+func (st *Structure) recomputeStats() {
+	sortModifiers(st.Modifiers)
+	s := applyModifiers(st.Base, st.Modifiers)
+	st.Health = healthAfterMaxChange(st.Health, st.MaxHealth, s.Health)
+	st.Attack, st.MaxHealth, st.Armor, st.Range = s.Attack, s.Health, s.Armor, s.Range
+}
+
+// refreshAuras rebuilds every unit's aura layer from the auras of the living
+// allied units currently on the board, teammates' included, and its terrain
+// layer from its tile.
+func refreshAuras(gs *GameState) {
+	for _, u := range gs.Units {
+		u.Modifiers = withoutModifiers(u.Modifiers, ModifierAura, "")
+	}
+	for _, source := range gs.Units {
+		card := gs.LookupCard(source.CardID)
+		if !source.IsAlive() || card == nil || card.Aura == nil {
+			continue
+		}
+		aura := card.Aura
+		for _, u := range gs.Units {
+			if u == source || !gs.AreAllies(u.PlayerIndex, source.PlayerIndex) || !u.IsAlive() {
+				continue
+			}
+			if abs(u.Position.Row-source.Position.Row)+abs(u.Position.Col-source.Position.Col) > aura.Range {
+				continue
+			}
+			if aura.Tribe != "" {
+				target := gs.LookupCard(u.CardID)
+				if target == nil || !strings.Contains(strings.ToLower(target.Name), strings.ToLower(aura.Tribe)) {
+					continue
+				}
+			}
+			u.Modifiers = append(u.Modifiers, StatModifier{
+				Layer:    ModifierAura,
+				SourceID: string(source.ID),
+				Attack:   aura.Attack,
+				Health:   aura.Health,
+				Armor:    aura.Armor,
+				Speed:    aura.Speed,
+			})
+		}
+	}
+	terrainModifiers(gs)
+	for _, u := range gs.Units {
+		u.recomputeStats()
+	}
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnitStatsComeFromBaseAndModifierStack(t *testing.T) {
+	gs := NewGameState("modifier-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	u := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 3, Speed: 1, Range: 1})
+	log := NewEventLog(gs.CurrentTurn)
+
+	u.AddModifier(StatModifier{Layer: ModifierEquipment, SourceID: "upgrade", Health: 2})
+	status := gs.ApplyUnitStatus(u, StatusAttack, 2, "bloodlust", gs.CurrentTurn, "fast", log)
+	if u.Attack != 3 || u.MaxHealth != 5 || u.Health != 5 {
+		t.Fatalf("Expected atk 3 and 5/5 hp, got atk=%d hp=%d/%d", u.Attack, u.Health, u.MaxHealth)
+	}
+	if u.Base.Attack != 1 || u.Base.Health != 3 {
+		t.Errorf("Expected base stats untouched, got %+v", u.Base)
+	}
+	if len(u.Modifiers) != 2 || u.Modifiers[0].Layer != ModifierEquipment || u.Modifiers[1].Layer != ModifierStatus {
+		t.Errorf("Expected equipment then status modifiers, got %+v", u.Modifiers)
+	}
+
+	gs.RemoveStatus(status.ID, "dispelled", "fast", log)
+	if u.Attack != 1 {
+		t.Errorf("Expected attack back to base, got %d", u.Attack)
+	}
+
+	data, err := json.Marshal(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := decoded["base"]; !ok {
+		t.Error("Expected serialized unit to include base stats")
+	}
+	if decoded["maxHealth"] != float64(5) {
+		t.Errorf("Expected serialized effective max health 5, got %v", decoded["maxHealth"])
+	}
+}
+
+func TestAuraBuffsAdjacentTribeUntilSourceDies(t *testing.T) {
+	gs := NewGameState("modifier-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	buffer := &Card{
+		ID:        "goblin_buffer",
+		Name:      "Goblin Buffer",
+		Type:      CardTypeUnit,
+		UnitStats: &UnitStats{Attack: 1, Health: 3, Range: 1},
+		Aura:      &AuraEffect{Range: 1, Tribe: "Goblin", Attack: 1},
+	}
+	goblin := &Card{ID: "goblin", Name: "Goblin", Type: CardTypeUnit, UnitStats: &UnitStats{Attack: 1, Health: 1, Range: 1}}
+	skeleton := &Card{ID: "skeleton", Name: "Skeleton", Type: CardTypeUnit, UnitStats: &UnitStats{Attack: 1, Health: 1, Range: 1}}
+	gs.SetCardCatalog([]*Card{buffer, goblin, skeleton})
+
+	src := gs.SpawnUnit(0, NewCardInstance(buffer.ID), buffer, Point{Row: 8, Col: 3})
+	g := gs.SpawnUnit(0, NewCardInstance(goblin.ID), goblin, Point{Row: 8, Col: 4})
+	s := gs.SpawnUnit(0, NewCardInstance(skeleton.ID), skeleton, Point{Row: 8, Col: 2})
+	far := gs.SpawnUnit(0, NewCardInstance(goblin.ID), goblin, Point{Row: 8, Col: 7})
+	refreshAuras(gs)
+
+	if g.Attack != 2 || s.Attack != 1 || far.Attack != 1 || src.Attack != 1 {
+		t.Fatalf("Expected only the adjacent goblin buffed, got goblin=%d skeleton=%d far=%d source=%d", g.Attack, s.Attack, far.Attack, src.Attack)
+	}
+
+	src.Health = 0
+	resolveDeaths(gs, NewEventLog(gs.CurrentTurn), "death")
+	if g.Attack != 1 || len(g.Modifiers) != 0 {
+		t.Errorf("Expected the aura to end with its source, got atk=%d mods=%+v", g.Attack, g.Modifiers)
+	}
+}
@@ -0,0 +1,339 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// movementIntent is a unit's request to enter a tile during one movement tick.
+// Intents for every unit are gathered before any of them are applied.
+type movementIntent struct {
+	Unit      *Unit
+	From      Point
+	To        Point
+	Cancelled bool
+	// Yielded intents lost a tile to an allied unit; the unit waits this tick
+	// and plans again in the next one
+	Yielded bool
+}
+
+// moving reports whether the intent will be applied this tick.
+func (in *movementIntent) moving() bool {
+	return !in.Cancelled && !in.Yielded
+}
+
+// forwardDirection returns the one-tile step a player's units take when
+// advancing toward the far side of the board, where the enemy command centers
+// stand. Only the row component is ever non-zero; Agile units change column
+// with their activated sidestep, and heading covers units that leave their
+// lane.
+func (gs *GameState) forwardDirection(playerIndex int) Point {
+	own := gs.GetCommandCenter(playerIndex)
+	if own == nil {
+		// Even-numbered players defend the bottom rows by default
+		if playerIndex%2 == 0 {
+			return Point{Row: -1}
+		}
+		return Point{Row: 1}
+	}
+	if own.TopLeftRow >= gs.BoardRows/2 {
+		return Point{Row: -1}
+	}
+	return Point{Row: 1}
+}
+
+// heading returns the one-tile step a unit takes from the given tile. Units
+// march down their lane toward the far side while an enemy command center
+// stands across from their player's base. Once none does, which only happens
+// with more than two players, they head for the nearest standing enemy command
+// center instead: sideways until they are in line with it, then along that
+// column.
+func (gs *GameState) heading(u *Unit, from Point) Point {
+	forward := gs.forwardDirection(u.PlayerIndex)
+	if gs.facesEnemy(u.PlayerIndex) {
+		return forward
+	}
+	target := gs.nearestEnemyCommandCenter(u.PlayerIndex, from)
+	if target == nil {
+		return forward
+	}
+	w, _ := target.Footprint.size()
+	switch {
+	case from.Col < target.TopLeftCol:
+		return Point{Col: 1}
+	case from.Col >= target.TopLeftCol+w:
+		return Point{Col: -1}
+	case from.Row < target.TopLeftRow:
+		return Point{Row: 1}
+	}
+	return Point{Row: -1}
+}
+
+// facesEnemy reports whether a standing enemy command center on the far side
+// of the board shares a column with the player's base. Players without a
+// command center or a zone map always march down their lanes.
+func (gs *GameState) facesEnemy(playerIndex int) bool {
+	own := gs.GetCommandCenter(playerIndex)
+	pz := gs.playerZones(playerIndex)
+	if own == nil || pz == nil {
+		return true
+	}
+	bottom := own.TopLeftRow >= gs.BoardRows/2
+	for _, cc := range gs.CommandCenters {
+		if gs.AreAllies(cc.PlayerIndex, playerIndex) || gs.IsEliminated(cc.PlayerIndex) || (cc.TopLeftRow >= gs.BoardRows/2) == bottom {
+			continue
+		}
+		w, _ := cc.Footprint.size()
+		for c := cc.TopLeftCol; c < cc.TopLeftCol+w; c++ {
+			for r := range pz.Tiles {
+				if gs.ZoneAt(playerIndex, r, c) == ZoneBase {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// nearestEnemyCommandCenter returns the standing enemy command center closest
+// to the tile, or nil if none is left. Ties go to the lower player index.
+func (gs *GameState) nearestEnemyCommandCenter(playerIndex int, from Point) *CommandCenter {
+	var best *CommandCenter
+	bestDist := 0
+	for _, cc := range gs.CommandCenters {
+		if gs.AreAllies(cc.PlayerIndex, playerIndex) || gs.IsEliminated(cc.PlayerIndex) {
+			continue
+		}
+		d := distanceToCommandCenter(from, cc)
+		if best == nil || d < bestDist || (d == bestDist && cc.PlayerIndex < best.PlayerIndex) {
+			best = cc
+			bestDist = d
+		}
+	}
+	return best
+}
+
+// resolveMovement advances every unit along its heading by up to its Speed.
+// Movement happens in ticks of one hop: each tick every unit that can still
+// move plans its route with findPath and declares its first hop as an intent,
+// conflicting intents are cancelled or yield, and the rest are applied
+// together. A hop is usually one tile forward, but movement keywords let a unit
+// pass over or through what is in its way, and such a hop costs every tile it
+// covers. A unit stops for the round once it has no hop left, is caught in a
+// collision, or enters terrain or a zone of control that stops movement, and
+// rooted or holding units do not move at all. Each hop is logged as its own
+// event.
+func resolveMovement(gs *GameState, log *EventLog) {
+	// Auras follow their units to their new tiles
+	defer refreshAuras(gs)
+	stopped := make(map[UnitID]bool)
+	moved := make(map[UnitID]int)
+	slipped := make(map[UnitID]bool)
+	// Units that never move this round are known before anyone plans a route
+	for _, u := range gs.Units {
+		if !gs.canMove(u) {
+			stopped[u.ID] = true
+		}
+	}
+
+	for {
+		var intents []*movementIntent
+		hops := make(map[*movementIntent]pathStep)
+		for _, u := range gs.Units {
+			if !u.IsAlive() || stopped[u.ID] || u.Speed <= moved[u.ID] {
+				continue
+			}
+			steps := gs.findPath(u, u.Position, u.Speed-moved[u.ID], moveOptions{slipped: slipped[u.ID], staying: stopped})
+			if len(steps) == 0 {
+				stopped[u.ID] = true
+				continue
+			}
+			in := &movementIntent{Unit: u, From: u.Position, To: steps[0].To}
+			intents = append(intents, in)
+			hops[in] = steps[0]
+		}
+		if len(intents) == 0 {
+			break
+		}
+
+		resolveMovementCollisions(gs, intents, log)
+
+		for _, in := range intents {
+			if in.Cancelled {
+				stopped[in.Unit.ID] = true
+				continue
+			}
+			if in.Yielded {
+				continue
+			}
+			hop := hops[in]
+			in.Unit.Position = in.To
+			moved[in.Unit.ID] += hop.Cost
+			slipped[in.Unit.ID] = slipped[in.Unit.ID] || hop.Slips
+			data := map[string]any{
+				"unitId":      in.Unit.ID,
+				"playerIndex": in.Unit.PlayerIndex,
+				"fromRow":     in.From.Row,
+				"fromCol":     in.From.Col,
+				"toRow":       in.To.Row,
+				"toCol":       in.To.Col,
+			}
+			if hop.Slips {
+				data["slipped"] = true
+			}
+			log.AddSimple(EventTypeMovement, "movement", data)
+			runMoveHooks(gs, in.Unit, in.From, in.To, log)
+			if hop.Stops {
+				stopped[in.Unit.ID] = true
+			}
+		}
+	}
+}
+
+// resolveMovementCollisions cancels conflicting intents before any are applied.
+// When opposing units would enter the same tile they all stay put and a
+// collision event names every unit involved. When only allied units would, the
+// one closest to the enemy command center it is heading for goes first, ties
+// going to the unit that entered play first, and the others yield for this
+// tick. Units that would swap tiles collide as well. Finally, an intent into a
+// tile whose occupant is not leaving is cancelled, or yields if the occupant
+// yielded, repeated until no more cancellations cascade. The result depends only on the set of intents, never
+// on their order.
+func resolveMovementCollisions(gs *GameState, intents []*movementIntent, log *EventLog) {
+	byDest := make(map[Point][]*movementIntent)
+	bySource := make(map[Point]*movementIntent)
+	for _, in := range intents {
+		byDest[in.To] = append(byDest[in.To], in)
+		bySource[in.From] = in
+	}
+
+	// Same destination
+	var contested []Point
+	for dest, group := range byDest {
+		if len(group) > 1 {
+			contested = append(contested, dest)
+		}
+	}
+	sort.Slice(contested, func(i, j int) bool {
+		if contested[i].Row != contested[j].Row {
+			return contested[i].Row < contested[j].Row
+		}
+		return contested[i].Col < contested[j].Col
+	})
+	for _, dest := range contested {
+		group := byDest[dest]
+		if gs.allAllied(group) {
+			first := group[0]
+			for _, in := range group[1:] {
+				if gs.movesBefore(in, first) {
+					first = in
+				}
+			}
+			for _, in := range group {
+				in.Yielded = in != first
+			}
+			continue
+		}
+		for _, in := range group {
+			in.Cancelled = true
+		}
+		logCollision(log, dest, group)
+	}
+
+	// Swaps
+	for _, in := range intents {
+		if !in.moving() {
+			continue
+		}
+		if other, ok := bySource[in.To]; ok && other.To == in.From && other.moving() {
+			in.Cancelled = true
+			other.Cancelled = true
+			logCollision(log, in.To, []*movementIntent{in, other})
+		}
+	}
+
+	// Occupied destinations whose occupant stays. A unit waiting behind one
+	// that yielded waits with it instead of stopping for the round.
+	for changed := true; changed; {
+		changed = false
+		for _, in := range intents {
+			if !in.moving() {
+				continue
+			}
+			if gs.UnitAt(in.To.Row, in.To.Col) == nil {
+				continue
+			}
+			leaving, ok := bySource[in.To]
+			if ok && leaving.moving() {
+				continue
+			}
+			if ok && leaving.Yielded {
+				in.Yielded = true
+			} else {
+				in.Cancelled = true
+			}
+			changed = true
+		}
+	}
+}
+
+// allAllied reports whether every unit in the group is on the same team.
+func (gs *GameState) allAllied(group []*movementIntent) bool {
+	for _, in := range group[1:] {
+		if !gs.AreAllies(in.Unit.PlayerIndex, group[0].Unit.PlayerIndex) {
+			return false
+		}
+	}
+	return true
+}
+
+// movesBefore reports whether intent a wins a tile over the allied intent b:
+// the unit closer to its nearest enemy command center goes first, then the
+// unit that entered play first.
+func (gs *GameState) movesBefore(a, b *movementIntent) bool {
+	da, db := gs.objectiveDistance(a.Unit, a.From), gs.objectiveDistance(b.Unit, b.From)
+	if da != db {
+		return da < db
+	}
+	return unitSeq(a.Unit.ID) < unitSeq(b.Unit.ID)
+}
+
+// objectiveDistance returns how far the tile is from the nearest standing
+// enemy command center of the unit's player, or 0 if none is left.
+func (gs *GameState) objectiveDistance(u *Unit, from Point) int {
+	if cc := gs.nearestEnemyCommandCenter(u.PlayerIndex, from); cc != nil {
+		return distanceToCommandCenter(from, cc)
+	}
+	return 0
+}
+
+// unitSeq returns the sequence number in a unit ID such as "unit-12", which
+// orders units by when they entered play.
+func unitSeq(id UnitID) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(string(id), "unit-"))
+	return n
+}
+
+// logCollision records a collision event naming all units involved, in the
+// order they entered play so the event is stable regardless of how the intents
+// were gathered.
+func logCollision(log *EventLog, tile Point, group []*movementIntent) {
+	units := make([]*Unit, 0, len(group))
+	for _, in := range group {
+		units = append(units, in.Unit)
+	}
+	sort.Slice(units, func(i, j int) bool { return unitSeq(units[i].ID) < unitSeq(units[j].ID) })
+	ids := make([]UnitID, 0, len(units))
+	players := make([]int, 0, len(units))
+	for _, u := range units {
+		ids = append(ids, u.ID)
+		players = append(players, u.PlayerIndex)
+	}
+	log.AddSimple(EventTypeCollision, "movement", map[string]any{
+		"row":           tile.Row,
+		"col":           tile.Col,
+		"unitIds":       ids,
+		"playerIndexes": players,
+	})
+}
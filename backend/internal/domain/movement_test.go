@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"testing"
+)
+
+func placeTestUnit(gs *GameState, playerIndex int, pos Point, stats UnitStats) *Unit {
+	card := &Card{ID: "test_unit", Type: CardTypeUnit, UnitStats: &stats}
+	return gs.SpawnUnit(playerIndex, NewCardInstance(card.ID), card, pos)
+}
+
+func TestMovementAdvancesBySpeed(t *testing.T) {
+	gs := NewGameState("move-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	u := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 2, Range: 1})
+
+	log := NewEventLog(gs.CurrentTurn)
+	resolveMovement(gs, log)
+
+	if u.Position != (Point{Row: 6, Col: 3}) {
+		t.Fatalf("Expected unit at (6,3), got %+v", u.Position)
+	}
+	moves := 0
+	for _, evt := range log.Events {
+		if evt.Type != EventTypeMovement {
+			continue
+		}
+		moves++
+		if evt.Data["fromRow"].(int)-1 != evt.Data["toRow"].(int) || evt.Data["toCol"] != 3 {
+			t.Errorf("Unexpected movement event data: %v", evt.Data)
+		}
+	}
+	if moves != 2 {
+		t.Errorf("Expected 2 movement events, got %d", moves)
+	}
+}
+
+func TestMovementStopsAtEnemyAndStructures(t *testing.T) {
+	gs := NewGameState("move-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	attacker := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 3, Range: 1})
+	placeTestUnit(gs, 1, Point{Row: 6, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 0, Range: 1})
+	cc := gs.GetCommandCenter(0)
+	raider := placeTestUnit(gs, 1, Point{Row: cc.TopLeftRow - 1, Col: cc.TopLeftCol}, UnitStats{Attack: 1, Health: 1, Speed: 2, Range: 1})
+
+	resolveMovement(gs, NewEventLog(gs.CurrentTurn))
+
+	if attacker.Position != (Point{Row: 7, Col: 3}) {
+		t.Errorf("Expected attacker to stop in front of the enemy at (7,3), got %+v", attacker.Position)
+	}
+	if raider.Position != (Point{Row: cc.TopLeftRow - 1, Col: cc.TopLeftCol}) {
+		t.Errorf("Expected raider to be blocked by the command center, got %+v", raider.Position)
+	}
+}
+
+func TestMovementCollisionCancelsBothUnits(t *testing.T) {
+	for _, reversed := range []bool{false, true} {
+		gs := NewGameState("move-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+		a := placeTestUnit(gs, 0, Point{Row: 6, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 2, Range: 1})
+		b := placeTestUnit(gs, 1, Point{Row: 4, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 2, Range: 1})
+		if reversed {
+			gs.Units[0], gs.Units[1] = gs.Units[1], gs.Units[0]
+		}
+
+		log := NewEventLog(gs.CurrentTurn)
+		resolveMovement(gs, log)
+
+		if a.Position != (Point{Row: 6, Col: 3}) || b.Position != (Point{Row: 4, Col: 3}) {
+			t.Errorf("reversed=%v: expected both units to stay put, got a=%+v b=%+v", reversed, a.Position, b.Position)
+		}
+		collisions := 0
+		for _, evt := range log.Events {
+			switch evt.Type {
+			case EventTypeMovement:
+				t.Errorf("reversed=%v: unexpected movement event %v", reversed, evt.Data)
+			case EventTypeCollision:
+				collisions++
+				ids := evt.Data["unitIds"].([]UnitID)
+				if len(ids) != 2 || ids[0] != a.ID || ids[1] != b.ID {
+					t.Errorf("reversed=%v: collision should name both units, got %v", reversed, ids)
+				}
+				if evt.Data["row"] != 5 || evt.Data["col"] != 3 {
+					t.Errorf("reversed=%v: unexpected collision tile %v", reversed, evt.Data)
+				}
+			}
+		}
+		if collisions != 1 {
+			t.Errorf("reversed=%v: expected 1 collision event, got %d", reversed, collisions)
+		}
+	}
+}
+
+func TestAlliedUnitsTakeTurnsForAVacatedTile(t *testing.T) {
+	for _, reversed := range []bool{false, true} {
+		gs := NewGameState("move-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+		leaving := placeTestUnit(gs, 0, Point{Row: 6, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 1, Range: 1})
+		behind := placeTestUnit(gs, 0, Point{Row: 7, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 1, Range: 1})
+		beside := placeTestUnit(gs, 0, Point{Row: 6, Col: 4}, UnitStats{Attack: 1, Health: 1, Speed: 1, Range: 1})
+		intents := []*movementIntent{
+			{Unit: leaving, From: leaving.Position, To: Point{Row: 5, Col: 3}},
+			{Unit: behind, From: behind.Position, To: Point{Row: 6, Col: 3}},
+			{Unit: beside, From: beside.Position, To: Point{Row: 6, Col: 3}},
+		}
+		if reversed {
+			intents[1], intents[2] = intents[2], intents[1]
+		}
+
+		log := NewEventLog(gs.CurrentTurn)
+		resolveMovementCollisions(gs, intents, log)
+
+		// The unit beside is a row closer to the enemy command center
+		for _, in := range intents {
+			if in.Cancelled || in.Yielded != (in.Unit == behind) {
+				t.Errorf("reversed=%v: expected only %s to yield the vacated tile, got %s cancelled=%v yielded=%v", reversed, behind.ID, in.Unit.ID, in.Cancelled, in.Yielded)
+			}
+		}
+		if len(log.Events) != 0 {
+			t.Errorf("reversed=%v: expected no collision between allies, got %v", reversed, log.Events)
+		}
+	}
+}
+
+func TestUnitsBehindAYieldingAllyWaitWithIt(t *testing.T) {
+	gs := NewGameState("move-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	front := placeTestUnit(gs, 0, Point{Row: 6, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 1, Range: 1})
+	middle := placeTestUnit(gs, 0, Point{Row: 7, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 1, Range: 1})
+	back := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 1, Range: 1})
+	// A row closer to the enemy command center, so it wins (5,3) over the front unit
+	beside := placeTestUnit(gs, 0, Point{Row: 5, Col: 4}, UnitStats{Attack: 1, Health: 1, Speed: 1, Range: 1})
+	intents := []*movementIntent{
+		{Unit: front, From: front.Position, To: Point{Row: 5, Col: 3}},
+		{Unit: middle, From: middle.Position, To: front.Position},
+		{Unit: back, From: back.Position, To: middle.Position},
+		{Unit: beside, From: beside.Position, To: Point{Row: 5, Col: 3}},
+	}
+
+	resolveMovementCollisions(gs, intents, NewEventLog(gs.CurrentTurn))
+
+	for _, in := range intents {
+		if in.Cancelled || in.Yielded != (in.Unit != beside) {
+			t.Errorf("Expected the column to yield behind its front unit, got %s cancelled=%v yielded=%v", in.Unit.ID, in.Cancelled, in.Yielded)
+		}
+	}
+}
+
+func TestCollisionListsUnitsInPlayOrder(t *testing.T) {
+	gs := NewGameState("move-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	early := placeTestUnit(gs, 0, Point{Row: 6, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 1, Range: 1})
+	gs.unitSeq = 9
+	late := placeTestUnit(gs, 1, Point{Row: 4, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 1, Range: 1})
+
+	log := NewEventLog(gs.CurrentTurn)
+	logCollision(log, Point{Row: 5, Col: 3}, []*movementIntent{{Unit: late}, {Unit: early}})
+
+	ids := log.Events[0].Data["unitIds"].([]UnitID)
+	if late.ID != "unit-10" || len(ids) != 2 || ids[0] != early.ID || ids[1] != late.ID {
+		t.Errorf("Expected %s before %s, got %v", early.ID, late.ID, ids)
+	}
+}
+
+func TestMovementFollowsVacatingAlly(t *testing.T) {
+	gs := NewGameState("move-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	front := placeTestUnit(gs, 0, Point{Row: 7, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 1, Range: 1})
+	back := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 1, Range: 1})
+	gs.Units[0], gs.Units[1] = gs.Units[1], gs.Units[0]
+
+	resolveMovement(gs, NewEventLog(gs.CurrentTurn))
+
+	if front.Position != (Point{Row: 6, Col: 3}) || back.Position != (Point{Row: 7, Col: 3}) {
+		t.Errorf("Expected the column to advance together, got front=%+v back=%+v", front.Position, back.Position)
+	}
+}
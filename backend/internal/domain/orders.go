@@ -0,0 +1,57 @@
+package domain
+
+// resolveOrder runs an order card's operations for the given play action.
+// A friendly_unit order applies to the allied unit on the targeted tile,
+// a teammate's included, and fizzles if that unit is gone or has changed
+// sides; a friendly_lane order applies to every allied unit in the targeted
+// column.
+func resolveOrder(gs *GameState, log *EventLog, step string, a Action, card *Card) {
+	data := map[string]any{
+		"playerIndex":    a.PlayerIndex,
+		"action":         string(a.Type),
+		"cardId":         card.ID,
+		"cardInstanceId": a.CardInHandID,
+		"row":            a.Position.Row,
+		"col":            a.Position.Col,
+		"targetType":     string(card.OrderEffect.TargetType),
+	}
+
+	var targets []*Unit
+	switch card.OrderEffect.TargetType {
+	case OrderTargetFriendlyLane:
+		for _, u := range gs.Units {
+			if gs.AreAllies(u.PlayerIndex, a.PlayerIndex) && u.Position.Col == a.Position.Col && u.IsAlive() {
+				targets = append(targets, u)
+			}
+		}
+	default:
+		var u *Unit
+		if a.TargetID != "" {
+			u = gs.GetUnit(UnitID(a.TargetID))
+		}
+		if u == nil {
+			u = gs.UnitAt(a.Position.Row, a.Position.Col)
+		}
+		if u == nil || !gs.AreAllies(u.PlayerIndex, a.PlayerIndex) || !u.IsAlive() {
+			data["fizzled"] = true
+			data["reason"] = PlacementInvalidTarget
+			log.AddSimple(EventTypeEffect, step, data)
+			return
+		}
+		data["targetId"] = u.ID
+		targets = append(targets, u)
+	}
+	log.AddSimple(EventTypeEffect, step, data)
+
+	for _, u := range targets {
+		runEffectOps(&effectContext{
+			gs:          gs,
+			log:         log,
+			step:        step,
+			playerIndex: a.PlayerIndex,
+			cardID:      card.ID,
+			tile:        a.Position,
+			unit:        u,
+		}, card.OrderEffect.Operations)
+	}
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"testing"
+)
+
+func rageOrder() *Card {
+	return &Card{
+		ID:       "rage",
+		Type:     CardTypeOrder,
+		ManaCost: 1,
+		OrderEffect: &OrderEffect{TargetType: OrderTargetFriendlyUnit, Operations: []EffectOp{
+			{Op: EffectOpBuff, Attack: 2, Duration: EffectDurationRound},
+		}},
+	}
+}
+
+func TestOrderBuffsFriendlyUnitInSpellWindow(t *testing.T) {
+	gs := NewGameState("orders-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.SetCardCatalog([]*Card{rageOrder()})
+	gs.PlayerStates = []PlayerBattleState{
+		{
+			PlayerIndex: 0,
+			HandLimit:   7,
+			Hand:        []CardInstance{{InstanceID: "rage-1", CardID: "rage"}},
+			Resources:   Resources{Mana: 1},
+		},
+		{PlayerIndex: 1},
+	}
+	u := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 2, Range: 1})
+
+	if reason := gs.ValidatePlacement(0, gs.LookupCard("rage"), u.Position.Row, u.Position.Col); reason != "" {
+		t.Fatalf("Expected a friendly unit to be a valid order target, got %q", reason)
+	}
+	if gs.IsTileOccupied(2, 2) {
+		t.Fatal("Expected an empty tile to be free")
+	}
+	gs.AddPlannedPlay(PlannedPlay{PlayerIndex: 0, CardInstance: "rage-1", CardID: "rage", Position: u.Position})
+
+	log := ExecuteResolutionPhase(gs, ActionQueue{}, ActionQueue{})
+
+	if u.Attack != 3 {
+		t.Errorf("Expected the order to give +2 ATK, got %d", u.Attack)
+	}
+	resolved := false
+	for _, e := range log.Events {
+		if e.Step == "fast" && e.Data["cardId"] == CardID("rage") {
+			resolved = true
+		}
+	}
+	if !resolved {
+		t.Error("Expected the order to resolve in the fast step")
+	}
+	ps := &gs.PlayerStates[0]
+	if ps.Resources.Mana != 0 {
+		t.Errorf("Expected the order's Mana to be paid, got %d", ps.Resources.Mana)
+	}
+	if len(ps.DiscardPile) != 1 || ps.DiscardPile[0].InstanceID != "rage-1" {
+		t.Errorf("Expected the order in the discard pile, got %v", ps.DiscardPile)
+	}
+}
+
+func TestOrderRejectsEnemyOrEmptyTarget(t *testing.T) {
+	gs := NewGameState("orders-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	card := rageOrder()
+	enemy := placeTestUnit(gs, 1, Point{Row: 3, Col: 3}, UnitStats{Attack: 1, Health: 2, Range: 1})
+
+	if reason := gs.ValidatePlacement(0, card, enemy.Position.Row, enemy.Position.Col); reason != PlacementInvalidTarget {
+		t.Errorf("Expected an enemy unit to be rejected, got %q", reason)
+	}
+	if reason := gs.ValidatePlacement(0, card, 6, 6); reason != PlacementInvalidTarget {
+		t.Errorf("Expected an empty tile to be rejected, got %q", reason)
+	}
+
+	// A target that changed hands before resolution fizzles
+	log := NewEventLog(gs.CurrentTurn)
+	resolveOrder(gs, log, "fast", Action{PlayerIndex: 0, Type: ActionTypePlayCard, Position: enemy.Position}, card)
+	if enemy.Attack != 1 {
+		t.Errorf("Expected the enemy unit to be unaffected, got %d ATK", enemy.Attack)
+	}
+	if len(log.Events) != 1 || log.Events[0].Data["fizzled"] != true {
+		t.Errorf("Expected a fizzled order event, got %v", log.Events)
+	}
+}
+
+func TestLaneOrderAffectsOnlyFriendlyUnitsInColumn(t *testing.T) {
+	gs := NewGameState("orders-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	card := &Card{
+		ID:   "forward",
+		Type: CardTypeOrder,
+		OrderEffect: &OrderEffect{TargetType: OrderTargetFriendlyLane, Operations: []EffectOp{
+			{Op: EffectOpBuff, Speed: 1, Duration: EffectDurationRound},
+		}},
+	}
+	a := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 2, Speed: 1, Range: 1})
+	b := placeTestUnit(gs, 0, Point{Row: 9, Col: 3}, UnitStats{Attack: 1, Health: 2, Speed: 1, Range: 1})
+	other := placeTestUnit(gs, 0, Point{Row: 8, Col: 4}, UnitStats{Attack: 1, Health: 2, Speed: 1, Range: 1})
+	enemy := placeTestUnit(gs, 1, Point{Row: 2, Col: 3}, UnitStats{Attack: 1, Health: 2, Speed: 1, Range: 1})
+
+	if reason := gs.ValidatePlacement(0, card, 5, 3); reason != "" {
+		t.Fatalf("Expected any tile of a lane to be a valid target, got %q", reason)
+	}
+	resolveOrder(gs, NewEventLog(gs.CurrentTurn), "fast", Action{PlayerIndex: 0, Type: ActionTypePlayCard, Position: Point{Row: 5, Col: 3}}, card)
+
+	if a.Speed != 2 || b.Speed != 2 {
+		t.Errorf("Expected friendly units in the lane to get +1 SPD, got %d and %d", a.Speed, b.Speed)
+	}
+	if other.Speed != 1 || enemy.Speed != 1 {
+		t.Errorf("Expected units outside the order to be unaffected, got %d and %d", other.Speed, enemy.Speed)
+	}
+}
@@ -0,0 +1,276 @@
+package domain
+
+// Movement keywords change how a unit may cross the board:
+//   - Flying passes over allied units, structures and terrain and ignores the
+//     zone of control of units that are not flying themselves, but still stops
+//     to engage an enemy unit in its way.
+//   - Phasing passes through units and structures.
+//   - Stealth, from the keyword or the Smoke Bomb status, slips past the first
+//     enemy unit it meets each round.
+//
+// A hop only ever moves along the unit's heading, which is down its lane unless
+// its player has no enemy left across the board. Agile's sideways step is an
+// activated ability resolved before movement (see agileStep); the move preview
+// counts its tiles as reachable. A unit never ends a hop on a tile it only
+// passes over, and a unit without one of these keywords simply advances tile by
+// tile.
+
+// pathStep is one hop of a unit's route: the tile it lands on and what the hop
+// costs and does.
+type pathStep struct {
+	To Point
+	// Cost is how many tiles of the unit's Speed the hop uses
+	Cost int
+	// Slips marks the hop that uses up Stealth to get past an enemy unit
+	Slips bool
+	// Stops marks a hop that ends the unit's movement for the round, into
+	// terrain that stops movement or an enemy zone of control
+	Stops bool
+}
+
+// moveMode is how a unit may move, from its keywords and statuses.
+type moveMode struct {
+	flying  bool
+	phasing bool
+	stealth bool
+}
+
+// moveOptions carries the round's movement state into findPath.
+type moveOptions struct {
+	// slipped is set once the unit's Stealth has been used this round
+	slipped bool
+	// staying are the units that will not move again this round; allies that
+	// are still moving are assumed to make room
+	staying map[UnitID]bool
+}
+
+// MovePreview is the route a unit would take this round if nothing else on
+// the board moved, and every tile it could legally end its move on.
+type MovePreview struct {
+	UnitID    UnitID  `json:"unitId"`
+	Path      []Point `json:"path"`
+	Reachable []Point `json:"reachable"`
+}
+
+// movementMode derives a unit's movement keywords.
+func (gs *GameState) movementMode(u *Unit) moveMode {
+	return moveMode{
+		flying:  u.HasKeyword(KeywordFlying),
+		phasing: u.HasKeyword(KeywordPhasing),
+		stealth: u.HasKeyword(KeywordStealth) || gs.HasStatus(string(u.ID), StatusStealth),
+	}
+}
+
+// PreviewMovement returns the unit's planned route and reachable tiles for
+// this round, or false if there is no such unit on the board.
+func (gs *GameState) PreviewMovement(id UnitID) (*MovePreview, bool) {
+	u := gs.GetUnit(id)
+	if u == nil || !u.IsAlive() {
+		return nil, false
+	}
+	preview := &MovePreview{UnitID: u.ID, Path: []Point{}, Reachable: []Point{}}
+	if !gs.canMove(u) {
+		return preview, true
+	}
+	staying := map[UnitID]bool{}
+	for _, other := range gs.Units {
+		if other != u && !gs.canMove(other) {
+			staying[other.ID] = true
+		}
+	}
+	opts := moveOptions{staying: staying}
+	// Follow the plan hop by hop as the unit would during the movement step
+	pos, budget := u.Position, u.Speed
+	for budget > 0 {
+		steps := gs.findPath(u, pos, budget, opts)
+		if len(steps) == 0 {
+			break
+		}
+		hop := steps[0]
+		preview.Path = append(preview.Path, hop.To)
+		pos = hop.To
+		budget -= hop.Cost
+		opts.slipped = opts.slipped || hop.Slips
+		if hop.Stops {
+			break
+		}
+	}
+	preview.Reachable = gs.reachableTiles(u, moveOptions{staying: staying})
+	return preview, true
+}
+
+// canMove reports whether the unit moves at all this round.
+func (gs *GameState) canMove(u *Unit) bool {
+	return u.IsAlive() && u.Speed > 0 && !u.Holding && !gs.HasStatus(string(u.ID), StatusRoot)
+}
+
+// findPath returns the unit's route from the given tile within budget tiles of
+// movement, hop by hop along its heading until it runs out of Speed, is
+// stopped or cannot go further. Returns nil if the unit should not move. A
+// ground unit that starts in an enemy zone of control, or faces an enemy it
+// cannot get past, stays where it is to fight.
+func (gs *GameState) findPath(u *Unit, from Point, budget int, opts moveOptions) []pathStep {
+	mode := gs.movementMode(u)
+	if !mode.flying && gs.inEnemyZOC(u, mode, from) {
+		return nil
+	}
+	if gs.engagedAhead(u, mode, from, opts.slipped) {
+		return nil
+	}
+	var steps []pathStep
+	pos, slipped := from, opts.slipped
+	for budget > 0 {
+		hop, ok := gs.forwardHop(u, mode, pos, slipped, opts)
+		if !ok || hop.Cost > budget {
+			break
+		}
+		steps = append(steps, hop)
+		pos, slipped, budget = hop.To, slipped || hop.Slips, budget-hop.Cost
+		if hop.Stops {
+			break
+		}
+	}
+	return steps
+}
+
+// reachableTiles returns every tile the unit could end its move on this round,
+// including where it stands, in row-major order: any point along its route,
+// and, for an Agile unit that has not used its ability yet, each sidestep tile
+// and any point along the route from there.
+func (gs *GameState) reachableTiles(u *Unit, opts moveOptions) []Point {
+	seen := map[Point]bool{}
+	mark := func(from Point) {
+		seen[from] = true
+		for _, step := range gs.findPath(u, from, u.Speed, opts) {
+			seen[step.To] = true
+		}
+	}
+	mark(u.Position)
+	if u.HasKeyword(KeywordAgile) && u.AbilityUsedTurn != gs.CurrentTurn {
+		for _, dc := range []int{-1, 1} {
+			if to := (Point{Row: u.Position.Row, Col: u.Position.Col + dc}); agileTileValid(gs, u, to) {
+				mark(to)
+			}
+		}
+	}
+	tiles := []Point{}
+	for r := 0; r < gs.BoardRows; r++ {
+		for c := 0; c < gs.BoardCols; c++ {
+			if p := (Point{Row: r, Col: c}); seen[p] {
+				tiles = append(tiles, p)
+			}
+		}
+	}
+	return tiles
+}
+
+// forwardHop walks along the unit's heading from a tile over anything the unit
+// can pass until it reaches a tile it can land on.
+func (gs *GameState) forwardHop(u *Unit, mode moveMode, from Point, slipped bool, opts moveOptions) (pathStep, bool) {
+	dir := gs.heading(u, from)
+	hop := pathStep{To: from}
+	for {
+		hop.To = Point{Row: hop.To.Row + dir.Row, Col: hop.To.Col + dir.Col}
+		hop.Cost++
+		switch gs.tileAccess(u, mode, hop.To, slipped, opts) {
+		case tileLandable:
+			hop.Stops = gs.stopsOn(u, mode, hop.To)
+			return hop, true
+		case tileSlip:
+			slipped = true
+			hop.Slips = true
+		case tileBlocked:
+			return pathStep{}, false
+		}
+		// A ground unit cannot pass a tile that would stop it
+		if gs.stopsOn(u, mode, hop.To) {
+			return pathStep{}, false
+		}
+	}
+}
+
+// tileAccess is what a tile means to a moving unit.
+type tileAccess int
+
+const (
+	tileBlocked tileAccess = iota
+	tileLandable
+	// tilePass can be crossed but not landed on
+	tilePass
+	// tileSlip can be crossed by using up the unit's Stealth
+	tileSlip
+)
+
+// tileAccess classifies a tile for a moving unit. Tiles held by allies that
+// are still moving count as landable; the movement step cancels the hop if
+// the ally stays put.
+func (gs *GameState) tileAccess(u *Unit, mode moveMode, p Point, slipped bool, opts moveOptions) tileAccess {
+	if !gs.InBounds(p.Row, p.Col) || !gs.canEnter(u, p.Row, p.Col) {
+		return tileBlocked
+	}
+	passes := mode.flying || mode.phasing
+	if gs.structureAt(p.Row, p.Col) {
+		if passes {
+			return tilePass
+		}
+		return tileBlocked
+	}
+	other := gs.UnitAt(p.Row, p.Col)
+	if other == nil || other == u {
+		return tileLandable
+	}
+	if gs.AreAllies(other.PlayerIndex, u.PlayerIndex) {
+		if !opts.staying[other.ID] {
+			return tileLandable
+		}
+		if passes {
+			return tilePass
+		}
+		return tileBlocked
+	}
+	switch {
+	case mode.phasing:
+		return tilePass
+	case mode.stealth && !slipped:
+		return tileSlip
+	}
+	return tileBlocked
+}
+
+// stopsOn reports whether entering the tile ends a ground unit's movement:
+// terrain that stops movement, or an enemy zone of control.
+func (gs *GameState) stopsOn(u *Unit, mode moveMode, p Point) bool {
+	if mode.flying {
+		return gs.inEnemyZOC(u, mode, p)
+	}
+	return gs.TerrainRulesAt(p.Row, p.Col).StopsMovement || gs.inEnemyZOC(u, mode, p)
+}
+
+// inEnemyZOC reports whether an enemy Taunt unit projects its zone of control
+// onto the tile. Flying units are only held by flying Taunt units.
+func (gs *GameState) inEnemyZOC(u *Unit, mode moveMode, p Point) bool {
+	for _, other := range gs.Units {
+		if !other.IsAlive() || gs.AreAllies(other.PlayerIndex, u.PlayerIndex) || !other.HasKeyword(KeywordTaunt) {
+			continue
+		}
+		if mode.flying && !other.HasKeyword(KeywordFlying) {
+			continue
+		}
+		if adjacent(other.Position, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// engagedAhead reports whether an enemy unit the unit cannot get past stands
+// directly ahead of it.
+func (gs *GameState) engagedAhead(u *Unit, mode moveMode, from Point, slipped bool) bool {
+	dir := gs.heading(u, from)
+	ahead := Point{Row: from.Row + dir.Row, Col: from.Col + dir.Col}
+	other := gs.UnitAt(ahead.Row, ahead.Col)
+	if other == nil || gs.AreAllies(other.PlayerIndex, u.PlayerIndex) {
+		return false
+	}
+	return gs.tileAccess(u, mode, ahead, slipped, moveOptions{}) == tileBlocked
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPhasingPassesThroughUnitsAndStructures(t *testing.T) {
+	gs := NewGameState("path-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	spirit := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 3, Range: 1})
+	spirit.Keywords = []Keyword{{ID: KeywordPhasing}}
+	placeTestUnit(gs, 1, Point{Row: 7, Col: 3}, UnitStats{Attack: 1, Health: 1, Range: 1})
+	gs.AddStructure(1, BuildingTower, BuildingStats{Health: 5}, Point{Row: 6, Col: 3})
+
+	log := NewEventLog(gs.CurrentTurn)
+	resolveMovement(gs, log)
+
+	if spirit.Position != (Point{Row: 5, Col: 3}) {
+		t.Errorf("Expected the phasing unit to pass through to (5,3), got %+v", spirit.Position)
+	}
+}
+
+func TestFlyingPassesOverAlliesAndStructuresButEngagesEnemies(t *testing.T) {
+	gs := NewGameState("path-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	flyer := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 3, Range: 1})
+	flyer.Keywords = []Keyword{{ID: KeywordFlying}}
+	holder := placeTestUnit(gs, 0, Point{Row: 7, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 1, Range: 1})
+	holder.Holding = true
+	gs.AddStructure(1, BuildingTower, BuildingStats{Health: 5}, Point{Row: 6, Col: 3})
+	placeTestUnit(gs, 1, Point{Row: 4, Col: 3}, UnitStats{Attack: 1, Health: 1, Range: 1})
+
+	resolveMovement(gs, NewEventLog(gs.CurrentTurn))
+
+	if flyer.Position != (Point{Row: 5, Col: 3}) {
+		t.Errorf("Expected the flyer to land in front of the enemy at (5,3), got %+v", flyer.Position)
+	}
+}
+
+func TestStealthSlipsPastTheFirstEnemyOnly(t *testing.T) {
+	for _, smokeBomb := range []bool{false, true} {
+		gs := NewGameState("path-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+		rogue := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 4, Range: 1})
+		if smokeBomb {
+			gs.ApplyUnitStatus(rogue, StatusStealth, 0, string(rogue.ID), gs.CurrentTurn, "fast", NewEventLog(gs.CurrentTurn))
+		} else {
+			rogue.Keywords = []Keyword{{ID: KeywordStealth}}
+		}
+		placeTestUnit(gs, 1, Point{Row: 7, Col: 3}, UnitStats{Attack: 1, Health: 1, Range: 1})
+		placeTestUnit(gs, 1, Point{Row: 5, Col: 3}, UnitStats{Attack: 1, Health: 1, Range: 1})
+
+		log := NewEventLog(gs.CurrentTurn)
+		resolveMovement(gs, log)
+
+		if rogue.Position != (Point{Row: 6, Col: 3}) {
+			t.Errorf("smokeBomb=%v: expected the unit to slip past one enemy and stop at (6,3), got %+v", smokeBomb, rogue.Position)
+		}
+		slipped := 0
+		for _, evt := range log.Events {
+			if evt.Type == EventTypeMovement && evt.Data["slipped"] == true {
+				slipped++
+			}
+		}
+		if slipped != 1 {
+			t.Errorf("smokeBomb=%v: expected one slipped move, got %d", smokeBomb, slipped)
+		}
+	}
+}
+
+func TestAgileUnitsKeepTheirColumnWhileMoving(t *testing.T) {
+	gs := NewGameState("path-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	scout := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 2, Range: 1})
+	scout.Keywords = []Keyword{{ID: KeywordAgile}}
+	gs.AddStructure(1, BuildingTower, BuildingStats{Health: 5}, Point{Row: 7, Col: 3})
+
+	resolveMovement(gs, NewEventLog(gs.CurrentTurn))
+
+	if scout.Position != (Point{Row: 8, Col: 3}) {
+		t.Errorf("Expected the agile unit to leave sidesteps to its activated ability, got %+v", scout.Position)
+	}
+}
+
+func TestPreviewMovementIncludesAgileSidesteps(t *testing.T) {
+	gs := NewGameState("path-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	scout := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 1, Range: 1})
+	scout.Keywords = []Keyword{{ID: KeywordAgile}}
+	gs.AddStructure(1, BuildingTower, BuildingStats{Health: 5}, Point{Row: 7, Col: 3})
+	placeTestUnit(gs, 0, Point{Row: 8, Col: 2}, UnitStats{Attack: 1, Health: 1, Range: 1})
+
+	preview, _ := gs.PreviewMovement(scout.ID)
+	want := []Point{{Row: 7, Col: 4}, {Row: 8, Col: 3}, {Row: 8, Col: 4}}
+	if !reflect.DeepEqual(preview.Reachable, want) {
+		t.Errorf("Expected the sidestep to (8,4) and the route from it to be reachable, got %v", preview.Reachable)
+	}
+
+	scout.AbilityUsedTurn = gs.CurrentTurn
+	if preview, _ := gs.PreviewMovement(scout.ID); !reflect.DeepEqual(preview.Reachable, []Point{{Row: 8, Col: 3}}) {
+		t.Errorf("Expected no sidesteps once the ability is used, got %v", preview.Reachable)
+	}
+}
+
+func TestTauntZoneOfControlStopsGroundUnits(t *testing.T) {
+	gs := NewGameState("path-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	guard := placeTestUnit(gs, 1, Point{Row: 6, Col: 4}, UnitStats{Attack: 1, Health: 3, Range: 1})
+	guard.Keywords = []Keyword{{ID: KeywordTaunt}}
+	runner := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 3, Range: 1})
+	flyer := placeTestUnit(gs, 0, Point{Row: 8, Col: 5}, UnitStats{Attack: 1, Health: 1, Speed: 3, Range: 1})
+	flyer.Keywords = []Keyword{{ID: KeywordFlying}}
+
+	resolveMovement(gs, NewEventLog(gs.CurrentTurn))
+
+	if runner.Position != (Point{Row: 6, Col: 3}) {
+		t.Errorf("Expected the ground unit to stop on entering the zone of control at (6,3), got %+v", runner.Position)
+	}
+	if flyer.Position != (Point{Row: 5, Col: 5}) {
+		t.Errorf("Expected the flyer to ignore a ground unit's zone of control, got %+v", flyer.Position)
+	}
+}
+
+func TestPreviewMovementMatchesResolution(t *testing.T) {
+	gs := NewGameState("path-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	scout := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 1, Speed: 3, Range: 1})
+	scout.Keywords = []Keyword{{ID: KeywordPhasing}}
+	gs.AddStructure(1, BuildingTower, BuildingStats{Health: 5}, Point{Row: 7, Col: 3})
+
+	preview, ok := gs.PreviewMovement(scout.ID)
+	if !ok {
+		t.Fatal("Expected a preview for a unit on the board")
+	}
+	want := []Point{{Row: 6, Col: 3}, {Row: 5, Col: 3}}
+	if !reflect.DeepEqual(preview.Path, want) {
+		t.Errorf("Expected the preview path %v, got %v", want, preview.Path)
+	}
+	if scout.Position != (Point{Row: 8, Col: 3}) || gs.UnitAt(8, 3) != scout {
+		t.Errorf("Expected previewing not to move the unit, got %+v", scout.Position)
+	}
+	reachable := map[Point]bool{}
+	for _, p := range preview.Reachable {
+		reachable[p] = true
+	}
+	for _, p := range []Point{{Row: 8, Col: 3}, {Row: 6, Col: 3}, {Row: 5, Col: 3}} {
+		if !reachable[p] {
+			t.Errorf("Expected %+v to be reachable, got %v", p, preview.Reachable)
+		}
+	}
+	if reachable[Point{Row: 7, Col: 3}] {
+		t.Error("Expected the structure's tile not to be reachable")
+	}
+
+	resolveMovement(gs, NewEventLog(gs.CurrentTurn))
+	if scout.Position != want[len(want)-1] {
+		t.Errorf("Expected resolution to follow the preview to %+v, got %+v", want[len(want)-1], scout.Position)
+	}
+
+	scout.Holding = true
+	if preview, _ := gs.PreviewMovement(scout.ID); len(preview.Path) != 0 || len(preview.Reachable) != 0 {
+		t.Errorf("Expected a holding unit to have no moves, got %+v", preview)
+	}
+	if _, ok := gs.PreviewMovement("missing"); ok {
+		t.Error("Expected no preview for an unknown unit")
+	}
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+)
+
+// passPriority hands the priority token to the next player still in the
+// match, counting seats the same way orderByPriority does. If every other
+// player is out, the token simply moves one seat on.
+func (gs *GameState) passPriority() {
+	n := gs.PlayerCount()
+	if n <= 0 {
+		return
+	}
+	for step := 1; step <= n; step++ {
+		if next := (gs.PriorityPlayer + step) % n; !gs.IsEliminated(next) {
+			gs.PriorityPlayer = next
+			return
+		}
+	}
+	gs.PriorityPlayer = (gs.PriorityPlayer + 1) % n
+}
+
+// orderByPriority sorts a speed bucket into resolution order: higher card
+// priority first, then players starting from the priority token holder, then
+// a tiebreak on the action's card instance ID derived from the match seed.
+func orderByPriority(gs *GameState, actions ActionQueue) ActionQueue {
+	n := max(gs.PlayerCount(), 1)
+	seat := func(a Action) int {
+		return ((a.PlayerIndex-gs.PriorityPlayer)%n + n) % n
+	}
+	sort.SliceStable(actions, func(i, j int) bool {
+		a, b := actions[i], actions[j]
+		if pa, pb := actionPriority(gs, a), actionPriority(gs, b); pa != pb {
+			return pa > pb
+		}
+		if sa, sb := seat(a), seat(b); sa != sb {
+			return sa < sb
+		}
+		return tiebreakKey(gs.Seed, a) < tiebreakKey(gs.Seed, b)
+	})
+	return actions
+}
+
+// actionPriority returns the priority of the card behind an action, or 0 if
+// the card is unknown.
+func actionPriority(gs *GameState, a Action) int {
+	if card := gs.LookupCard(CardID(a.SourceID)); card != nil {
+		return card.Priority
+	}
+	return 0
+}
+
+// tiebreakKey hashes an action's instance ID with the match seed so ties are
+// broken the same way every time a match is replayed, without favouring
+// either player's ID scheme.
+func tiebreakKey(seed int64, a Action) uint64 {
+	id := a.CardInHandID
+	if id == "" {
+		id = a.SourceID
+	}
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(seed))
+	h.Write(buf[:])
+	h.Write([]byte(id))
+	return h.Sum64()
+}
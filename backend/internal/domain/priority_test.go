@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestOrderByPriorityUsesCardPriorityThenTokenHolder(t *testing.T) {
+	gs := NewGameState("priority-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.SetCardCatalog([]*Card{
+		{ID: "bolt", Type: CardTypeSpell},
+		{ID: "quick", Type: CardTypeSpell, Priority: 1},
+	})
+	gs.PriorityPlayer = 1
+
+	actions := ActionQueue{
+		{PlayerIndex: 0, SourceID: "bolt", CardInHandID: "a"},
+		{PlayerIndex: 1, SourceID: "bolt", CardInHandID: "b"},
+		{PlayerIndex: 0, SourceID: "quick", CardInHandID: "c"},
+	}
+	ordered := orderByPriority(gs, actions)
+	got := []string{ordered[0].CardInHandID, ordered[1].CardInHandID, ordered[2].CardInHandID}
+	want := []string{"c", "b", "a"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected order %v, got %v", want, got)
+		}
+	}
+
+	gs.AdvanceTurn()
+	if gs.PriorityPlayer != 0 {
+		t.Errorf("Expected the token to pass to player 0, got %d", gs.PriorityPlayer)
+	}
+}
+
+func TestOrderByPriorityTiebreakIsSeeded(t *testing.T) {
+	gs := NewGameState("priority-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.SetSeed(7)
+	order := func() []string {
+		actions := ActionQueue{
+			{PlayerIndex: 0, CardInHandID: "x"},
+			{PlayerIndex: 0, CardInHandID: "y"},
+			{PlayerIndex: 0, CardInHandID: "z"},
+		}
+		var ids []string
+		for _, a := range orderByPriority(gs, actions) {
+			ids = append(ids, a.CardInHandID)
+		}
+		return ids
+	}
+	first, second := order(), order()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Expected the same tiebreak order, got %v and %v", first, second)
+		}
+	}
+}
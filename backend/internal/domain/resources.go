@@ -23,7 +23,13 @@ type BuildingType string
 
 const (
 	BuildingCommandCenter BuildingType = "command_center"
-	// Future building types can be added here
+	// BuildingTower generates Mana and shoots nearby enemy units
+	BuildingTower BuildingType = "tower"
+	// BuildingBarracks generates Gold
+	BuildingBarracks BuildingType = "barracks"
+	// BuildingCardStructure is a structure built from a building card; it
+	// generates the income its card defines
+	BuildingCardStructure BuildingType = "card_structure"
 )
 
 // BuildingLevel represents the level of a building
@@ -51,10 +57,23 @@ type Building struct {
 	TurnsSinceUpgrade int                `json:"turnsSinceUpgrade"`
 	LastUpgradeTime   time.Time          `json:"lastUpgradeTime"`
 	ResourceGen       ResourceGeneration `json:"resourceGeneration"`
+	// Bonus is extra generation on top of the type and level, e.g. from hero passives
+	Bonus             ResourceGeneration `json:"bonus"`
+	// Income is the generation defined by a building card
+	Income            ResourceGeneration `json:"income"`
 }
 
-// GetResourceGeneration returns the resource generation for a building based on its type and level
+// GetResourceGeneration returns the resource generation for a building based on its type and level,
+// plus any bonus it has been granted
 func (b *Building) GetResourceGeneration() ResourceGeneration {
+	gen := b.baseResourceGeneration()
+	gen.Gold += b.Bonus.Gold
+	gen.Mana += b.Bonus.Mana
+	return gen
+}
+
+// baseResourceGeneration returns the generation for the building's type and level alone
+func (b *Building) baseResourceGeneration() ResourceGeneration {
 	switch b.Type {
 	case BuildingCommandCenter:
 		switch b.Level {
@@ -67,6 +86,12 @@ func (b *Building) GetResourceGeneration() ResourceGeneration {
 		default:
 			return ResourceGeneration{Gold: 3, Mana: 2}
 		}
+	case BuildingTower:
+		return ResourceGeneration{Gold: 0, Mana: 1}
+	case BuildingBarracks:
+		return ResourceGeneration{Gold: 1, Mana: 0}
+	case BuildingCardStructure:
+		return b.Income
 	default:
 		return ResourceGeneration{Gold: 0, Mana: 0}
 	}
@@ -102,22 +102,22 @@ func TestGameStateResourceGeneration(t *testing.T) {
 		// Process resource generation
 		gameState.ProcessResourceGeneration()
 		
-		// Check that resources were generated based on command centers
+		// Check that resources were generated based on command centers and starter structures
 		for i := range gameState.PlayerStates {
 			ps := &gameState.PlayerStates[i]
 			
-			// Should have Level 1 command center resources
-			if ps.Resources.Gold != 3 {
-				t.Errorf("Player %d: expected 3 gold, got %d", i, ps.Resources.Gold)
+			// Should have Level 1 command center resources plus Barracks gold and Tower mana
+			if ps.Resources.Gold != 4 {
+				t.Errorf("Player %d: expected 4 gold, got %d", i, ps.Resources.Gold)
 			}
-			if ps.Resources.Mana != 2 {
-				t.Errorf("Player %d: expected 2 mana, got %d", i, ps.Resources.Mana)
+			if ps.Resources.Mana != 3 {
+				t.Errorf("Player %d: expected 3 mana, got %d", i, ps.Resources.Mana)
 			}
-			if ps.ResourceIncome.Gold != 3 {
-				t.Errorf("Player %d: expected 3 gold income, got %d", i, ps.ResourceIncome.Gold)
+			if ps.ResourceIncome.Gold != 4 {
+				t.Errorf("Player %d: expected 4 gold income, got %d", i, ps.ResourceIncome.Gold)
 			}
-			if ps.ResourceIncome.Mana != 2 {
-				t.Errorf("Player %d: expected 2 mana income, got %d", i, ps.ResourceIncome.Mana)
+			if ps.ResourceIncome.Mana != 3 {
+				t.Errorf("Player %d: expected 3 mana income, got %d", i, ps.ResourceIncome.Mana)
 			}
 		}
 	})
@@ -145,19 +145,19 @@ func TestGameStateResourceGeneration(t *testing.T) {
 		
 		// Check that gold accumulated and mana was reset
 		ps0 := &gameState.PlayerStates[0]
-		if ps0.Resources.Gold != 13 { // 10 + 3 from level 1 command center
-			t.Errorf("Player 0: expected 13 gold (accumulated), got %d", ps0.Resources.Gold)
+		if ps0.Resources.Gold != 14 { // 10 + 3 from level 1 command center + 1 from Barracks
+			t.Errorf("Player 0: expected 14 gold (accumulated), got %d", ps0.Resources.Gold)
 		}
-		if ps0.Resources.Mana != 2 { // Reset to 2 from level 1 command center
-			t.Errorf("Player 0: expected 2 mana (reset), got %d", ps0.Resources.Mana)
+		if ps0.Resources.Mana != 3 { // Reset to 2 from level 1 command center + 1 from Tower
+			t.Errorf("Player 0: expected 3 mana (reset), got %d", ps0.Resources.Mana)
 		}
 		
 		ps1 := &gameState.PlayerStates[1]
-		if ps1.Resources.Gold != 18 { // 15 + 3 from level 1 command center
-			t.Errorf("Player 1: expected 18 gold (accumulated), got %d", ps1.Resources.Gold)
+		if ps1.Resources.Gold != 19 { // 15 + 3 from level 1 command center + 1 from Barracks
+			t.Errorf("Player 1: expected 19 gold (accumulated), got %d", ps1.Resources.Gold)
 		}
-		if ps1.Resources.Mana != 2 { // Reset to 2 from level 1 command center
-			t.Errorf("Player 1: expected 2 mana (reset), got %d", ps1.Resources.Mana)
+		if ps1.Resources.Mana != 3 { // Reset to 2 from level 1 command center + 1 from Tower
+			t.Errorf("Player 1: expected 3 mana (reset), got %d", ps1.Resources.Mana)
 		}
 	})
 	
@@ -264,11 +264,11 @@ func TestBuildingUpgradeIntegration(t *testing.T) {
 		
 		for i := range gameState.PlayerStates {
 			ps := &gameState.PlayerStates[i]
-			if ps.Resources.Gold != 6 { // Level 2 generates 6 gold
-				t.Errorf("Player %d: expected 6 gold from level 2, got %d", i, ps.Resources.Gold)
+			if ps.Resources.Gold != 7 { // Level 2 generates 6 gold, Barracks 1
+				t.Errorf("Player %d: expected 7 gold from level 2, got %d", i, ps.Resources.Gold)
 			}
-			if ps.Resources.Mana != 4 { // Level 2 generates 4 mana
-				t.Errorf("Player %d: expected 4 mana from level 2, got %d", i, ps.Resources.Mana)
+			if ps.Resources.Mana != 5 { // Level 2 generates 4 mana, Tower 1
+				t.Errorf("Player %d: expected 5 mana from level 2, got %d", i, ps.Resources.Mana)
 			}
 		}
 	})
@@ -293,21 +293,21 @@ func TestBuildingUpgradeIntegration(t *testing.T) {
 			expectedMana := 0
 			expectedLevel := Level1
 			
-			// Buildings upgrade every 3 turns:
-			// Turn 1-3: Level 1 (3 gold, 2 mana per turn)
-			// Turn 4-7: Level 2 (6 gold, 4 mana per turn) - upgrades at start of turn 4
-			// Turn 8+: Level 3 (10 gold, 6 mana per turn) - upgrades at start of turn 8
+			// Buildings upgrade every 3 turns (Barracks adds 1 gold, Tower 1 mana on top):
+			// Turn 1-3: Level 1 (4 gold, 3 mana per turn)
+			// Turn 4-7: Level 2 (7 gold, 5 mana per turn) - upgrades at start of turn 4
+			// Turn 8+: Level 3 (11 gold, 7 mana per turn) - upgrades at start of turn 8
 			if turn <= 3 {
-				expectedGold = 3 * turn  // 3 gold per turn at level 1
-				expectedMana = 2          // 2 mana (reset each turn)
+				expectedGold = 4 * turn  // 4 gold per turn at level 1
+				expectedMana = 3          // 3 mana (reset each turn)
 				expectedLevel = Level1
 			} else if turn <= 7 {
-				expectedGold = 3*3 + 6*(turn-3)  // 9 gold from first 3 turns, then 6 per turn at level 2
-				expectedMana = 4                  // 4 mana at level 2
+				expectedGold = 4*3 + 7*(turn-3)  // 12 gold from first 3 turns, then 7 per turn at level 2
+				expectedMana = 5                  // 5 mana at level 2
 				expectedLevel = Level2
 			} else {
-				expectedGold = 3*3 + 6*4 + 10*(turn-7)  // 9 + 24 = 33 gold from first 7 turns, then 10 per turn at level 3
-				expectedMana = 6                         // 6 mana at level 3
+				expectedGold = 4*3 + 7*4 + 11*(turn-7)  // 12 + 28 = 40 gold from first 7 turns, then 11 per turn at level 3
+				expectedMana = 7                         // 7 mana at level 3
 				expectedLevel = Level3
 			}
 			
@@ -334,4 +334,73 @@ func TestBuildingUpgradeIntegration(t *testing.T) {
 			}
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestCardPlayCosts(t *testing.T) {
+	newCostState := func() *GameState {
+		gs := NewGameState("cost-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+		gs.SetCardCatalog([]*Card{
+			{ID: "grunt", Type: CardTypeUnit, GoldCost: 2, UnitStats: &UnitStats{Health: 1}},
+			{ID: "bolt", Type: CardTypeSpell, ManaCost: 2, SpellEffect: &SpellEffect{TargetType: "unit"}},
+		})
+		gs.PlayerStates = []PlayerBattleState{
+			{
+				PlayerIndex: 0,
+				Resources:   Resources{Gold: 3, Mana: 2},
+				Hand: []CardInstance{
+					{InstanceID: "g-1", CardID: "grunt"},
+					{InstanceID: "g-2", CardID: "grunt"},
+					{InstanceID: "b-1", CardID: "bolt"},
+				},
+			},
+			{PlayerIndex: 1},
+		}
+		return gs
+	}
+
+	t.Run("staging counts every planned play", func(t *testing.T) {
+		gs := newCostState()
+		grunt := gs.LookupCard("grunt")
+		if !gs.CanAffordPlay(0, "g-1", grunt) {
+			t.Fatal("First grunt should be affordable")
+		}
+		gs.AddPlannedPlay(PlannedPlay{PlayerIndex: 0, CardInstance: "g-1", CardID: "grunt", Position: Point{Row: 9, Col: 2}})
+		if gs.CanAffordPlay(0, "g-2", grunt) {
+			t.Error("Second grunt should exceed the 3 gold available")
+		}
+		if !gs.CanAffordPlay(0, "g-1", grunt) {
+			t.Error("Re-staging the same card should not count it twice")
+		}
+		if !gs.CanAffordPlay(0, "b-1", gs.LookupCard("bolt")) {
+			t.Error("Mana spell should still be affordable")
+		}
+	})
+
+	t.Run("resolution deducts costs and fizzles unaffordable plays", func(t *testing.T) {
+		gs := newCostState()
+		gs.AddPlannedPlay(PlannedPlay{PlayerIndex: 0, CardInstance: "g-1", CardID: "grunt", Position: Point{Row: 9, Col: 2}})
+		gs.AddPlannedPlay(PlannedPlay{PlayerIndex: 0, CardInstance: "g-2", CardID: "grunt", Position: Point{Row: 9, Col: 3}})
+
+		log := ExecuteResolutionPhase(gs, ActionQueue{}, ActionQueue{})
+
+		ps := &gs.PlayerStates[0]
+		if ps.Resources.Gold != 1 {
+			t.Errorf("Expected 1 gold left after one grunt, got %d", ps.Resources.Gold)
+		}
+		if len(gs.Units) != 1 {
+			t.Errorf("Expected only the paid grunt to be summoned, got %d units", len(gs.Units))
+		}
+		fizzled := false
+		for _, evt := range log.Events {
+			if evt.Data["reason"] == "out_of_resources" && evt.Data["cardInstanceId"] == CardInstanceID("g-2") {
+				fizzled = true
+			}
+		}
+		if !fizzled {
+			t.Error("Expected the unaffordable play to fizzle with out_of_resources")
+		}
+		if len(ps.Hand) != 2 {
+			t.Errorf("Fizzled card should stay in hand, hand=%v", ps.Hand)
+		}
+	})
+}
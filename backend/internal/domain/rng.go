@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+// SetSeed fixes the match seed and restarts the match's random stream from it.
+// Replaying a match from the same seed and inputs reproduces every shuffle,
+// draw and CPU choice. Terrain is not laid out again; a map whose terrain is
+// generated takes its seed through NewGameStateFromMap.
+func (gs *GameState) SetSeed(seed int64) {
+	gs.Seed = seed
+	gs.rng = rand.New(rand.NewSource(seed))
+}
+
+// Rand returns the match's random stream, starting it from Seed on first use.
+// All match randomness must come from here.
+func (gs *GameState) Rand() *rand.Rand {
+	if gs.rng == nil {
+		gs.rng = rand.New(rand.NewSource(gs.Seed))
+	}
+	return gs.rng
+}
+
+// ShuffleCards shuffles cards in place using the match's random stream.
+func (gs *GameState) ShuffleCards(cards []CardInstance) {
+	r := gs.Rand()
+	// Fisher-Yates
+	for i := len(cards) - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+}
+
+// NewCardInstance creates a card instance whose ID is drawn from the match's
+// random stream, so instance IDs are reproducible from the seed as well.
+func (gs *GameState) NewCardInstance(cardID CardID) CardInstance {
+	id, err := uuid.NewRandomFromReader(gs.Rand())
+	if err != nil {
+		return NewCardInstance(cardID)
+	}
+	return CardInstance{InstanceID: CardInstanceID(id.String()), CardID: cardID}
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestSameSeedReproducesShufflesAndReshuffles(t *testing.T) {
+	build := func(seed int64) *GameState {
+		gs := NewGameState("seed-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+		gs.SetSeed(seed)
+		var pile []CardInstance
+		for i := 0; i < 20; i++ {
+			pile = append(pile, gs.NewCardInstance("pawn"))
+		}
+		gs.ShuffleCards(pile)
+		gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0, DiscardPile: pile}}
+		reshuffleDiscard(gs, &gs.PlayerStates[0])
+		return gs
+	}
+
+	a, b := build(42), build(42)
+	pa, pb := a.PlayerStates[0].DrawPile, b.PlayerStates[0].DrawPile
+	if len(pa) != 20 || len(pb) != 20 {
+		t.Fatalf("Expected 20 cards in each draw pile, got %d and %d", len(pa), len(pb))
+	}
+	for i := range pa {
+		if pa[i].InstanceID != pb[i].InstanceID {
+			t.Fatalf("Draw piles diverge at %d: %s vs %s", i, pa[i].InstanceID, pb[i].InstanceID)
+		}
+	}
+	if a.Rand().Int63() != b.Rand().Int63() {
+		t.Error("Expected the random streams to stay in step")
+	}
+
+	c := build(43)
+	same := true
+	for i := range pa {
+		if pa[i].InstanceID != c.PlayerStates[0].DrawPile[i].InstanceID {
+			same = false
+			break
+		}
+	}
+	if same {
+		t.Error("Expected a different seed to produce a different draw pile")
+	}
+}
@@ -1,7 +1,6 @@
 package domain
 
 import (
-    "math/rand"
     "time"
 )
 
@@ -9,14 +8,16 @@ import (
 // 1) Start-of-upkeep triggers (placeholder hooks)
 // 2) Generate resources (Gold income added to bank; Mana refilled to ManaMax)
 // 3) Draw up to hand limit
-// 4) Update turn counters (placeholder hooks)
+// 4) Update turn counters (hero respawn cooldowns)
+// 5) End the match if deck exhaustion destroyed a command center
 func ExecuteUpkeepPhase(gameState *GameState) *EventLog {
     if gameState == nil {
         return NewEventLog(0)
     }
     evtLog := NewEventLog(gameState.CurrentTurn)
     evtLog.AddSimple(EventTypeRoundStart, "upkeep", map[string]any{
-        "turn": gameState.CurrentTurn,
+        "turn":           gameState.CurrentTurn,
+        "priorityPlayer": gameState.PriorityPlayer,
     })
 
     // 1) Start-of-upkeep triggers (no-op placeholder)
@@ -45,7 +46,7 @@ func ExecuteUpkeepPhase(gameState *GameState) *EventLog {
         ps := &gameState.PlayerStates[i]
         handLimit := ps.HandLimit
         if handLimit <= 0 {
-            handLimit = 7
+            handLimit = DefaultHandLimit
             ps.HandLimit = handLimit
         }
         toDraw := handLimit - len(ps.Hand)
@@ -61,82 +62,63 @@ func ExecuteUpkeepPhase(gameState *GameState) *EventLog {
         }
     }
 
-    // 4) Update turn counters (placeholder)
+    // 4) Update turn counters
+    tickHeroCooldowns(gameState, evtLog)
     evtLog.AddSimple(EventTypeTrigger, "upkeep", map[string]any{
         "note": "turn_counters_updated",
     })
 
+    // Deck exhaustion can destroy a command center during upkeep
+    if result := gameState.CheckMatchEnd(); result != nil {
+        evtLog.AddSimple(EventTypeMatchEnd, "upkeep", matchEndData(result))
+    }
+
     gameState.UpdatedAt = time.Now()
     return evtLog
 }
 
-// ExecuteResolutionPhase resolves both players' action queues in strict order
+// ExecuteResolutionPhase resolves every player's action queue in strict order
 // and applies end-of-round cleanup effects. Returns a detailed EventLog.
-func ExecuteResolutionPhase(gameState *GameState, player1Actions ActionQueue, player2Actions ActionQueue) *EventLog {
+func ExecuteResolutionPhase(gameState *GameState, playerActions ...ActionQueue) *EventLog {
     evtLog := NewEventLog(gameState.CurrentTurn)
 
-    // 0) Reveal & resolve planned plays for this round
-    // For now, only log the play and move the card from hand to discard.
-    if gameState != nil && gameState.PlannedPlays != nil {
-        for playerIndex, plays := range gameState.PlannedPlays {
-            // Defensive bounds check
-            if playerIndex < 0 || playerIndex >= len(gameState.PlayerStates) {
-                continue
-            }
-            ps := &gameState.PlayerStates[playerIndex]
-            for _, p := range plays {
-                // Log reveal/play event with target tile
-                evtLog.AddSimple(EventTypeEffect, "reveal", map[string]any{
-                    "playerIndex":    playerIndex,
-                    "action":         string(ActionTypePlayCard),
-                    "cardId":         p.CardID,
-                    "cardInstanceId": p.CardInstance,
-                    "row":            p.Position.Row,
-                    "col":            p.Position.Col,
-                })
-                // Move card instance from hand to discard if present
-                for h := 0; h < len(ps.Hand); h++ {
-                    if ps.Hand[h].InstanceID == p.CardInstance {
-                        card := ps.Hand[h]
-                        ps.Hand = append(ps.Hand[:h], ps.Hand[h+1:]...)
-                        ps.DiscardPile = append(ps.DiscardPile, card)
-                        h--
-                        break
-                    }
-                }
-            }
-        }
-        // Clear planned plays after processing
-        gameState.ClearPlannedPlays()
-    }
+    // 0) Reveal planned plays for this round. Every played card leaves the
+    // hand or command zone; unit, hero and building cards are held for the
+    // summon step and spells and orders are queued as fast play_card actions.
+    summons, spells := revealPlannedPlays(gameState, evtLog)
 
-    // Helper: combine two queues with player attribution already set in Action
-    allActions := append(ActionQueue{}, player1Actions...)
-    allActions = append(allActions, player2Actions...)
+    // Helper: combine the queues with player attribution already set in Action.
+    // Each speed bucket is put in priority order before it resolves.
+    allActions := append(ActionQueue{}, spells...)
+    for _, queue := range playerActions {
+        allActions = append(allActions, queue...)
+    }
 
     // 1) "Fast" Speed Step
-    fast := filterBySpeed(allActions, ActionSpeedFast)
+    fast := orderByPriority(gameState, filterBySpeed(allActions, ActionSpeedFast))
     resolveUniversalStep(gameState, evtLog, "fast", fast)
 
-    // 2) Movement Step — automatic movement (placeholder, no player-submitted movement)
-    evtLog.AddSimple(EventTypeMovement, "movement", map[string]any{
-        "note": "automatic_unit_movement_resolved",
-    })
+    // 2) Summon Step — revealed units enter the board and buildings are built
+    resolveSummons(gameState, evtLog, summons)
+
+    // 3) Movement Step — automatic movement (no player-submitted movement)
+    resolveMovement(gameState, evtLog)
 
-    // 3) "Normal" Speed Step
-    normal := filterBySpeed(allActions, ActionSpeedNormal)
+    // 4) "Normal" Speed Step
+    normal := orderByPriority(gameState, filterBySpeed(allActions, ActionSpeedNormal))
     resolveUniversalStep(gameState, evtLog, "normal", normal)
 
-    // 4) Combat Step — automatic simultaneous combat (placeholder)
-    evtLog.AddSimple(EventTypeDamage, "combat", map[string]any{
-        "note": "automatic_simultaneous_combat_resolved",
-    })
+    // 5) Combat Step — automatic simultaneous combat
+    resolveCombat(gameState, evtLog)
+
+    // 6) Death Resolution Step — remove destroyed units and fire death triggers
+    resolveDeaths(gameState, evtLog, "death")
 
-    // 5) "Slow" Speed Step
-    slow := filterBySpeed(allActions, ActionSpeedSlow)
+    // 7) "Slow" Speed Step
+    slow := orderByPriority(gameState, filterBySpeed(allActions, ActionSpeedSlow))
     resolveUniversalStep(gameState, evtLog, "slow", slow)
 
-    // 6) End of Round Cleanup Step
+    // 8) End of Round Cleanup Step
     // - Process queued discards
     for i := range gameState.PlayerStates {
         ps := &gameState.PlayerStates[i]
@@ -177,16 +159,16 @@ func ExecuteResolutionPhase(gameState *GameState, player1Actions ActionQueue, pl
         }
     }
 
-    // - End of Round triggers (placeholder)
-    evtLog.AddSimple(EventTypeTrigger, "end_of_round", map[string]any{
-        "note": "end_of_round_triggers_resolved",
-    })
+    // - End of Round triggers
+    runEndOfRoundHooks(gameState, evtLog)
 
-    // - Win/Loss check
-    if gameState.IsGameOver() {
-        evtLog.AddSimple(EventTypeRoundEnd, "end_of_round", map[string]any{
-            "winner": gameState.GetWinner(),
-        })
+    // - Win/Loss check: command centers first, then the turn limit
+    result := gameState.CheckMatchEnd()
+    if result == nil {
+        result = gameState.checkTurnLimit()
+    }
+    if result != nil {
+        evtLog.AddSimple(EventTypeMatchEnd, "end_of_round", matchEndData(result))
     }
 
     gameState.UpdatedAt = time.Now()
@@ -195,6 +177,82 @@ func ExecuteResolutionPhase(gameState *GameState, player1Actions ActionQueue, pl
 
 // --- Helpers ---
 
+// revealPlannedPlays logs each staged play in player order, pays its cost and
+// takes the card out of its owner's hand or command zone. Unit, hero and
+// building cards known to the catalog are returned for the summon step, and
+// spells and orders are returned as fast play_card actions targeting the staged
+// tile. Every other card goes to the discard pile once revealed. A play its
+// owner can no longer afford fizzles.
+func revealPlannedPlays(gs *GameState, log *EventLog) ([]PlannedPlay, ActionQueue) {
+    if gs == nil || gs.PlannedPlays == nil {
+        return nil, nil
+    }
+    var summons []PlannedPlay
+    var spells ActionQueue
+    for playerIndex := range gs.PlayerStates {
+        ps := &gs.PlayerStates[playerIndex]
+        for _, p := range gs.PlannedPlays[playerIndex] {
+            // Log reveal/play event with target tile
+            log.AddSimple(EventTypeEffect, "reveal", map[string]any{
+                "playerIndex":    playerIndex,
+                "action":         string(ActionTypePlayCard),
+                "cardId":         p.CardID,
+                "cardInstanceId": p.CardInstance,
+                "row":            p.Position.Row,
+                "col":            p.Position.Col,
+            })
+            // Find the card in hand or the command zone; plays for cards no
+            // longer held are dropped
+            if _, ok := ps.PlayableCard(p.CardInstance); !ok {
+                continue
+            }
+            // Pay for the card; a play that can no longer be afforded fizzles
+            // and the card stays in hand
+            def := gs.LookupCard(p.CardID)
+            if def != nil {
+                if !gs.SpendResources(playerIndex, def.Cost()) {
+                    log.AddSimple(EventTypeEffect, "reveal", map[string]any{
+                        "playerIndex":    playerIndex,
+                        "cardId":         p.CardID,
+                        "cardInstanceId": p.CardInstance,
+                        "fizzled":        true,
+                        "reason":         "out_of_resources",
+                    })
+                    continue
+                }
+                if def.GoldCost > 0 || def.ManaCost > 0 {
+                    log.AddSimple(EventTypeResource, "reveal", map[string]any{
+                        "playerIndex": playerIndex,
+                        "goldSpent":   def.GoldCost,
+                        "manaSpent":   def.ManaCost,
+                        "gold":        ps.Resources.Gold,
+                        "mana":        ps.Resources.Mana,
+                    })
+                }
+            }
+            card, _ := ps.takePlayableCard(p.CardInstance)
+            if def != nil && (def.IsUnit() || def.IsHero() || def.IsBuilding()) {
+                summons = append(summons, p)
+                continue
+            }
+            if def != nil && ((def.IsSpell() && def.SpellEffect != nil) || (def.IsOrder() && def.OrderEffect != nil)) {
+                spells = append(spells, Action{
+                    PlayerIndex:  playerIndex,
+                    Type:         ActionTypePlayCard,
+                    Speed:        ActionSpeedFast,
+                    SourceID:     string(p.CardID),
+                    Position:     p.Position,
+                    CardInHandID: string(p.CardInstance),
+                })
+            }
+            ps.DiscardPile = append(ps.DiscardPile, card)
+        }
+    }
+    // Clear planned plays after processing
+    gs.ClearPlannedPlays()
+    return summons, spells
+}
+
 func filterBySpeed(actions ActionQueue, speed ActionSpeed) ActionQueue {
     out := make(ActionQueue, 0, len(actions))
     for _, a := range actions {
@@ -216,6 +274,8 @@ func filterByType(actions ActionQueue, t ActionType) ActionQueue {
 }
 
 // resolveUniversalStep applies the universal rule: Movement -> Damage -> Other Effects.
+// Spell and order plays execute their card's operations and activated abilities resolve;
+// units killed by them are resolved before the step ends.
 func resolveUniversalStep(gs *GameState, log *EventLog, step string, actions ActionQueue) {
     if len(actions) == 0 {
         return
@@ -223,41 +283,46 @@ func resolveUniversalStep(gs *GameState, log *EventLog, step string, actions Act
     // Other effects (resolve play_card/activate_ability payloads)
     for _, a := range actions {
         switch a.Type {
-        case ActionTypePlayCard, ActionTypeActivateAbility:
+        case ActionTypeActivateAbility:
+            resolveActivatedAbility(gs, log, step, a)
+        case ActionTypePlayCard:
+            if card := gs.LookupCard(CardID(a.SourceID)); card != nil && card.IsSpell() && card.SpellEffect != nil {
+                resolveSpell(gs, log, step, a, card)
+                continue
+            }
+            if card := gs.LookupCard(CardID(a.SourceID)); card != nil && card.IsOrder() && card.OrderEffect != nil {
+                resolveOrder(gs, log, step, a, card)
+                continue
+            }
             log.AddSimple(EventTypeEffect, step, map[string]any{
-                "playerIndex": a.PlayerIndex,
-                "sourceId":    a.SourceID,
+                "playerIndex":  a.PlayerIndex,
+                "sourceId":     a.SourceID,
                 "cardInHandId": a.CardInHandID,
-                "targetId":    a.TargetID,
+                "targetId":     a.TargetID,
             })
         }
     }
+    resolveDeaths(gs, log, step)
 }
 
-// resolveMovementCollisions processes movement and cancels collisions to the same destination.
-// Movement and combat are automatic in this simplified server core; detailed
-// unit systems will replace these placeholders in future iterations.
-
-// resolveCombatSimultaneous applies attacks' damage simultaneously.
-// resolveCombatSimultaneous removed; no player-submitted attack actions.
-
-// extractTargetPlayerIndex/extractDamageAmount removed with debug actions.
-
 // drawCardsDeterministic draws up to count cards, shuffling discard into draw if needed.
-// Returns the number of cards actually drawn.
+// Drawing stops once the hand reaches HandHardCap. Returns the number of cards actually drawn.
 func drawCardsDeterministic(gs *GameState, ps *PlayerBattleState, count int, log *EventLog) int {
     if count <= 0 {
         return 0
     }
     drawn := 0
     for i := 0; i < count; i++ {
+        if len(ps.Hand) >= HandHardCap {
+            break
+        }
         if len(ps.DrawPile) == 0 {
             // If discard also empty, cannot draw
             if len(ps.DiscardPile) == 0 {
                 break
             }
             // Reshuffle discard into draw pile and apply deck exhaustion penalty
-            reshuffleDiscard(ps)
+            reshuffleDiscard(gs, ps)
             // Apply penalty: -25 HP to own command center
             gs.DealDamageToCommandCenter(ps.PlayerIndex, 25)
             log.AddSimple(EventTypeEffect, "upkeep", map[string]any{
@@ -277,17 +342,11 @@ func drawCardsDeterministic(gs *GameState, ps *PlayerBattleState, count int, log
     return drawn
 }
 
-func reshuffleDiscard(ps *PlayerBattleState) {
-    // Move all discard into draw and shuffle
+func reshuffleDiscard(gs *GameState, ps *PlayerBattleState) {
+    // Move all discard into draw and shuffle with the match's random stream
     ps.DrawPile = append(ps.DrawPile, ps.DiscardPile...)
     ps.DiscardPile = nil
-    // Deterministic enough per match seed would be better; use time seed fallback
-    r := rand.New(rand.NewSource(time.Now().UnixNano()))
-    // Fisher-Yates
-    for i := len(ps.DrawPile) - 1; i > 0; i-- {
-        j := r.Intn(i + 1)
-        ps.DrawPile[i], ps.DrawPile[j] = ps.DrawPile[j], ps.DrawPile[i]
-    }
+    gs.ShuffleCards(ps.DrawPile)
     ps.DeckCount = len(ps.DrawPile)
 }
 
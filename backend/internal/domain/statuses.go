@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// StatusID uniquely identifies an applied status within a single game.
+type StatusID string
+
+// StatusType names a temporary effect on a unit or structure.
+type StatusType string
+
+const (
+	// StatusAttack adds Magnitude Attack.
+	StatusAttack StatusType = "attack"
+	// StatusHealth adds Magnitude to Health and MaxHealth.
+	StatusHealth StatusType = "health"
+	// StatusSpeed adds Magnitude Speed.
+	StatusSpeed StatusType = "speed"
+	// StatusArmor adds Magnitude Armor.
+	StatusArmor StatusType = "armor"
+	// StatusSlow removes Magnitude Speed.
+	StatusSlow StatusType = "slow"
+	// StatusRoot stops the unit from moving.
+	StatusRoot StatusType = "root"
+	// StatusStealth gives the unit Stealth.
+	StatusStealth StatusType = "stealth"
+	// StatusEvade makes the next combat hit against the unit miss. It is
+	// consumed by that hit.
+	StatusEvade StatusType = "evade"
+)
+
+// Status target types, matching the targetType used in events.
+const (
+	StatusTargetUnit      = "unit"
+	StatusTargetStructure = "structure"
+)
+
+// StatusPermanent as ExpiresAtRound keeps a status until it is consumed or
+// its target leaves the board.
+const StatusPermanent = -1
+
+// StatusEffect is a status applied to a unit or structure. It lasts through
+// the Cleanup of round ExpiresAtRound.
+type StatusEffect struct {
+	ID             StatusID   `json:"id"`
+	Type           StatusType `json:"type"`
+	TargetType     string     `json:"targetType"`
+	TargetID       string     `json:"targetId"`
+	Magnitude      int        `json:"magnitude"`
+	SourceID       string     `json:"sourceId,omitempty"`
+	ExpiresAtRound int        `json:"expiresAtRound"`
+}
+
+// ApplyUnitStatus applies a status to a unit and logs a status_applied event.
+func (gs *GameState) ApplyUnitStatus(u *Unit, t StatusType, magnitude int, sourceID string, expiresAtRound int, step string, log *EventLog) StatusEffect {
+	return gs.applyStatus(StatusEffect{
+		Type:           t,
+		TargetType:     StatusTargetUnit,
+		TargetID:       string(u.ID),
+		Magnitude:      magnitude,
+		SourceID:       sourceID,
+		ExpiresAtRound: expiresAtRound,
+	}, step, log)
+}
+
+// ApplyStructureStatus applies a status to a structure and logs a
+// status_applied event.
+func (gs *GameState) ApplyStructureStatus(st *Structure, t StatusType, magnitude int, sourceID string, expiresAtRound int, step string, log *EventLog) StatusEffect {
+	return gs.applyStatus(StatusEffect{
+		Type:           t,
+		TargetType:     StatusTargetStructure,
+		TargetID:       string(st.ID),
+		Magnitude:      magnitude,
+		SourceID:       sourceID,
+		ExpiresAtRound: expiresAtRound,
+	}, step, log)
+}
+
+// applyStatus adds the status and returns a copy of it. Statuses move as the
+// slice changes, so callers keep its ID rather than a pointer.
+func (gs *GameState) applyStatus(s StatusEffect, step string, log *EventLog) StatusEffect {
+	gs.statusSeq++
+	s.ID = StatusID(fmt.Sprintf("status-%d", gs.statusSeq))
+	gs.Statuses = append(gs.Statuses, s)
+	gs.addStatusModifier(s)
+	log.AddSimple(EventTypeStatusApplied, step, statusEventData(s))
+	gs.UpdatedAt = time.Now()
+	return s
+}
+
+// HasStatus reports whether the unit or structure with the given ID carries
+// a status of the given type.
+func (gs *GameState) HasStatus(targetID string, t StatusType) bool {
+	for _, s := range gs.Statuses {
+		if s.TargetID == targetID && s.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveStatus takes a status off its target early, e.g. when it is consumed,
+// and logs a status_expired event with the reason.
+func (gs *GameState) RemoveStatus(id StatusID, reason, step string, log *EventLog) {
+	for i, s := range gs.Statuses {
+		if s.ID != id {
+			continue
+		}
+		gs.Statuses = append(gs.Statuses[:i], gs.Statuses[i+1:]...)
+		gs.removeStatusModifier(s)
+		data := statusEventData(s)
+		data["reason"] = reason
+		log.AddSimple(EventTypeStatusExpired, step, data)
+		return
+	}
+}
+
+// expireStatuses removes statuses whose last round has ended and statuses
+// whose target has left the board. Called during Cleanup.
+func expireStatuses(gs *GameState, log *EventLog) {
+	kept := gs.Statuses[:0]
+	var expired []StatusEffect
+	for _, s := range gs.Statuses {
+		switch {
+		case !gs.statusTargetExists(s):
+			// Target already gone; nothing to revert or show
+		case s.ExpiresAtRound != StatusPermanent && s.ExpiresAtRound <= gs.CurrentTurn:
+			expired = append(expired, s)
+		default:
+			kept = append(kept, s)
+		}
+	}
+	gs.Statuses = kept
+	for _, s := range expired {
+		gs.removeStatusModifier(s)
+		data := statusEventData(s)
+		data["reason"] = "expired"
+		log.AddSimple(EventTypeStatusExpired, "cleanup", data)
+	}
+}
+
+// statusModifier returns the stat change a status puts on its target's
+// modifier stack.
+func statusModifier(s StatusEffect) StatModifier {
+	m := StatModifier{Layer: ModifierStatus, SourceID: string(s.ID)}
+	switch s.Type {
+	case StatusAttack:
+		m.Attack = s.Magnitude
+	case StatusHealth:
+		m.Health = s.Magnitude
+	case StatusSpeed:
+		m.Speed = s.Magnitude
+	case StatusArmor:
+		m.Armor = s.Magnitude
+	case StatusSlow:
+		m.Speed = -s.Magnitude
+	}
+	return m
+}
+
+func (gs *GameState) addStatusModifier(s StatusEffect) {
+	switch s.TargetType {
+	case StatusTargetUnit:
+		if u := gs.GetUnit(UnitID(s.TargetID)); u != nil {
+			u.AddModifier(statusModifier(s))
+		}
+	case StatusTargetStructure:
+		if st := gs.structureByID(StructureID(s.TargetID)); st != nil {
+			st.AddModifier(statusModifier(s))
+		}
+	}
+}
+
+func (gs *GameState) removeStatusModifier(s StatusEffect) {
+	switch s.TargetType {
+	case StatusTargetUnit:
+		if u := gs.GetUnit(UnitID(s.TargetID)); u != nil {
+			u.RemoveModifiers(ModifierStatus, string(s.ID))
+		}
+	case StatusTargetStructure:
+		if st := gs.structureByID(StructureID(s.TargetID)); st != nil {
+			st.RemoveModifiers(ModifierStatus, string(s.ID))
+		}
+	}
+}
+
+func (gs *GameState) statusTargetExists(s StatusEffect) bool {
+	switch s.TargetType {
+	case StatusTargetUnit:
+		u := gs.GetUnit(UnitID(s.TargetID))
+		return u != nil && u.IsAlive()
+	case StatusTargetStructure:
+		return gs.structureByID(StructureID(s.TargetID)) != nil
+	}
+	return false
+}
+
+func (gs *GameState) structureByID(id StructureID) *Structure {
+	for _, st := range gs.Structures {
+		if st.ID == id {
+			return st
+		}
+	}
+	return nil
+}
+
+func statusEventData(s StatusEffect) map[string]any {
+	return map[string]any{
+		"statusId":       s.ID,
+		"status":         string(s.Type),
+		"targetType":     s.TargetType,
+		"targetId":       s.TargetID,
+		"magnitude":      s.Magnitude,
+		"sourceId":       s.SourceID,
+		"expiresAtRound": s.ExpiresAtRound,
+	}
+}
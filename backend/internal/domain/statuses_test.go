@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestRootOnHitStopsMovementUntilItExpires(t *testing.T) {
+	gs := NewGameState("status-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	attacker := placeTestUnit(gs, 0, Point{Row: 6, Col: 3}, UnitStats{Attack: 1, Health: 5, Range: 1})
+	attacker.Keywords = []Keyword{{ID: KeywordRoot}}
+	target := placeTestUnit(gs, 1, Point{Row: 5, Col: 3}, UnitStats{Attack: 0, Health: 5, Speed: 2, Range: 1})
+
+	log := NewEventLog(gs.CurrentTurn)
+	resolveCombat(gs, log)
+	if !gs.HasStatus(string(target.ID), StatusRoot) {
+		t.Fatal("Expected the target to be rooted")
+	}
+	applied := 0
+	for _, evt := range log.Events {
+		if evt.Type == EventTypeStatusApplied {
+			applied++
+		}
+	}
+	if applied != 1 {
+		t.Errorf("Expected one status_applied event, got %d", applied)
+	}
+
+	// Clear the attacker out of the way so only the root holds the target
+	gs.RemoveUnit(attacker.ID)
+	resolveMovement(gs, log)
+	if target.Position != (Point{Row: 5, Col: 3}) {
+		t.Errorf("Expected the rooted unit to stay put, got %+v", target.Position)
+	}
+
+	expireStatuses(gs, log)
+	if !gs.HasStatus(string(target.ID), StatusRoot) {
+		t.Fatal("Expected the root to last through next round")
+	}
+	gs.CurrentTurn++
+	expireStatuses(gs, log)
+	if gs.HasStatus(string(target.ID), StatusRoot) {
+		t.Error("Expected the root to expire in the next round's cleanup")
+	}
+}
+
+func TestEvadeStatusPreventsOneHit(t *testing.T) {
+	gs := NewGameState("status-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	placeTestUnit(gs, 0, Point{Row: 6, Col: 3}, UnitStats{Attack: 2, Health: 5, Range: 1})
+	target := placeTestUnit(gs, 1, Point{Row: 5, Col: 3}, UnitStats{Health: 5, Range: 1})
+	target.Keywords = []Keyword{{ID: KeywordEvade}}
+	log := NewEventLog(gs.CurrentTurn)
+	evadeOnSummon(gs, target, Keyword{ID: KeywordEvade}, log)
+
+	resolveCombat(gs, log)
+	if target.Health != 5 || gs.HasStatus(string(target.ID), StatusEvade) {
+		t.Fatalf("Expected the hit to be evaded and the status consumed, hp=%d", target.Health)
+	}
+	resolveCombat(gs, log)
+	if target.Health != 3 {
+		t.Errorf("Expected the second hit to land, hp=%d", target.Health)
+	}
+}
+
+func TestEvadedHitAppliesNoOnHitEffects(t *testing.T) {
+	gs := NewGameState("status-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	attacker := placeTestUnit(gs, 0, Point{Row: 6, Col: 3}, UnitStats{Attack: 2, Health: 5, Range: 1})
+	attacker.Keywords = []Keyword{{ID: KeywordSlow, Value: 1}, {ID: KeywordRoot}, {ID: KeywordLifesteal, Value: 2}}
+	attacker.Health = 3
+	target := placeTestUnit(gs, 1, Point{Row: 5, Col: 3}, UnitStats{Health: 5, Speed: 2, Range: 1})
+	target.Keywords = []Keyword{{ID: KeywordEvade}}
+	log := NewEventLog(gs.CurrentTurn)
+	evadeOnSummon(gs, target, Keyword{ID: KeywordEvade}, log)
+
+	resolveCombat(gs, log)
+	if target.Health != 5 {
+		t.Fatalf("Expected the hit to be evaded, hp=%d", target.Health)
+	}
+	if gs.HasStatus(string(target.ID), StatusSlow) || gs.HasStatus(string(target.ID), StatusRoot) {
+		t.Error("Expected an evaded hit not to Slow or Root the target")
+	}
+	if attacker.Health != 3 {
+		t.Errorf("Expected an evaded hit not to trigger Lifesteal, hp=%d", attacker.Health)
+	}
+
+	resolveCombat(gs, log)
+	if !gs.HasStatus(string(target.ID), StatusSlow) || !gs.HasStatus(string(target.ID), StatusRoot) || attacker.Health != 5 {
+		t.Errorf("Expected the landed hit to apply its on-hit effects, attacker hp=%d", attacker.Health)
+	}
+}
+
+func TestStructureStatusRevertsOnExpiry(t *testing.T) {
+	gs := NewGameState("status-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	tower := gs.PlayerStructures(0)[0]
+	log := NewEventLog(gs.CurrentTurn)
+
+	gs.ApplyStructureStatus(tower, StatusArmor, 2, "test", gs.CurrentTurn, "fast", log)
+	if tower.Armor != 3 {
+		t.Fatalf("Expected armor 3, got %d", tower.Armor)
+	}
+	expireStatuses(gs, log)
+	if tower.Armor != 1 || len(gs.Statuses) != 0 {
+		t.Errorf("Expected armor back to 1, got %d with %d statuses", tower.Armor, len(gs.Statuses))
+	}
+}
@@ -0,0 +1,247 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// StructureID uniquely identifies a structure on the board within a single game.
+type StructureID string
+
+// Footprint is the size of the rectangle of tiles a structure covers,
+// extending down and right from its top-left tile. A zero footprint covers a
+// single tile.
+type Footprint struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// size returns the footprint's width and height, treating zero as one tile.
+func (f Footprint) size() (int, int) {
+	return max(1, f.Width), max(1, f.Height)
+}
+
+// covers reports whether the footprint anchored at topLeft covers the tile.
+func (f Footprint) covers(topLeft Point, row, col int) bool {
+	w, h := f.size()
+	return row >= topLeft.Row && row < topLeft.Row+h &&
+		col >= topLeft.Col && col < topLeft.Col+w
+}
+
+// tiles lists every tile the footprint anchored at topLeft covers, row by row.
+func (f Footprint) tiles(topLeft Point) []Point {
+	w, h := f.size()
+	out := make([]Point, 0, w*h)
+	for r := topLeft.Row; r < topLeft.Row+h; r++ {
+		for c := topLeft.Col; c < topLeft.Col+w; c++ {
+			out = append(out, Point{Row: r, Col: c})
+		}
+	}
+	return out
+}
+
+// distance is the Manhattan distance from p to the closest tile the footprint
+// anchored at topLeft covers.
+func (f Footprint) distance(topLeft Point, p Point) int {
+	w, h := f.size()
+	dr := max(max(0, topLeft.Row-p.Row), p.Row-(topLeft.Row+h-1))
+	dc := max(max(0, topLeft.Col-p.Col), p.Col-(topLeft.Col+w-1))
+	return dr + dc
+}
+
+// Structure is a building on the board other than a command center: a
+// player's starter Tower and Barracks, or one built from a building card. It
+// covers the tiles of its footprint from Position, its top-left tile, can be
+// attacked, and generates resources for its owner while it stands.
+type Structure struct {
+	ID          StructureID `json:"id"`
+	PlayerIndex int         `json:"playerIndex"`
+	Position    Point       `json:"position"`
+	Footprint   Footprint   `json:"footprint"`
+	// Base stats come from the building's stats; Modifiers is the ordered
+	// stack applied on top of them
+	Base      UnitStats      `json:"base"`
+	Modifiers []StatModifier `json:"modifiers,omitempty"`
+	// Effective stats, recomputed from Base and Modifiers. Health is current HP.
+	Health    int `json:"health"`
+	MaxHealth int `json:"maxHealth"`
+	Armor     int `json:"armor"`
+	// Attack and Range are zero for structures that cannot shoot
+	Attack   int       `json:"attack"`
+	Range    int       `json:"range"`
+	Building *Building `json:"building"`
+	// CardID and CardInstanceID link a structure built from a card back to it
+	CardID         CardID         `json:"cardId,omitempty"`
+	CardInstanceID CardInstanceID `json:"cardInstanceId,omitempty"`
+}
+
+// IsDestroyed returns true if the structure has no health remaining.
+func (s *Structure) IsDestroyed() bool {
+	return s.Health <= 0
+}
+
+// starterStructureStats are the stats of the buildings every player starts with.
+var starterStructureStats = map[BuildingType]BuildingStats{
+	BuildingTower:    {Health: 30, Armor: 1, Attack: intPtr(2), Range: intPtr(2)},
+	BuildingBarracks: {Health: 30, Armor: 1},
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+// AddStructure places a new single-tile structure of the given building type on
+// the board. Returns nil if the tile is off the board or already blocked.
+func (gs *GameState) AddStructure(playerIndex int, buildingType BuildingType, stats BuildingStats, pos Point) *Structure {
+	return gs.addStructureWithFootprint(playerIndex, buildingType, stats, pos, Footprint{Width: 1, Height: 1})
+}
+
+// addStructureWithFootprint places a structure covering footprint from its
+// top-left tile pos. Returns nil if any covered tile is off the board or blocked.
+func (gs *GameState) addStructureWithFootprint(playerIndex int, buildingType BuildingType, stats BuildingStats, pos Point, footprint Footprint) *Structure {
+	for _, t := range footprint.tiles(pos) {
+		if !gs.InBounds(t.Row, t.Col) || gs.isTileBlocked(t.Row, t.Col) {
+			return nil
+		}
+	}
+	gs.structureSeq++
+	st := &Structure{
+		ID:          StructureID(fmt.Sprintf("structure-%d", gs.structureSeq)),
+		PlayerIndex: playerIndex,
+		Position:    pos,
+		Footprint:   footprint,
+		Base:        UnitStats{Health: stats.Health, Armor: stats.Armor},
+		Health:      stats.Health,
+		MaxHealth:   stats.Health,
+		Building:    NewBuilding(buildingType, playerIndex, pos.Row, pos.Col),
+	}
+	if stats.Attack != nil {
+		st.Base.Attack = *stats.Attack
+	}
+	if stats.Range != nil {
+		st.Base.Range = *stats.Range
+	}
+	if stats.Income != nil {
+		st.Building.Income = *stats.Income
+		st.Building.ResourceGen = st.Building.GetResourceGeneration()
+	}
+	st.recomputeStats()
+	gs.Structures = append(gs.Structures, st)
+	gs.UpdatedAt = time.Now()
+	return st
+}
+
+// StructureAt returns the structure whose footprint covers the given tile, or nil.
+func (gs *GameState) StructureAt(row, col int) *Structure {
+	for _, st := range gs.Structures {
+		if st.Footprint.covers(st.Position, row, col) {
+			return st
+		}
+	}
+	return nil
+}
+
+// distanceTo is the Manhattan distance from p to the structure's closest tile.
+func (st *Structure) distanceTo(p Point) int {
+	return st.Footprint.distance(st.Position, p)
+}
+
+// PlayerStructures returns the structures owned by a player in board order.
+func (gs *GameState) PlayerStructures(playerIndex int) []*Structure {
+	var out []*Structure
+	for _, st := range gs.Structures {
+		if st.PlayerIndex == playerIndex {
+			out = append(out, st)
+		}
+	}
+	return out
+}
+
+// DealDamageToStructure deals damage to a structure, removing it from the
+// board once destroyed. A destroyed structure built from a card sends the card
+// to its owner's discard pile. Returns true if the structure is destroyed.
+func (gs *GameState) DealDamageToStructure(st *Structure, damage int) bool {
+	st.Health = max(0, st.Health-damage)
+	gs.UpdatedAt = time.Now()
+	if !st.IsDestroyed() {
+		return false
+	}
+	for i, other := range gs.Structures {
+		if other == st {
+			gs.Structures = append(gs.Structures[:i], gs.Structures[i+1:]...)
+			break
+		}
+	}
+	if st.CardInstanceID != "" && st.PlayerIndex >= 0 && st.PlayerIndex < len(gs.PlayerStates) {
+		ps := &gs.PlayerStates[st.PlayerIndex]
+		ps.DiscardPile = append(ps.DiscardPile, CardInstance{InstanceID: st.CardInstanceID, CardID: st.CardID})
+	}
+	return true
+}
+
+// BuildStructure places the structure a building card creates. Returns nil if
+// the card has no building stats or the tile cannot hold it, which includes
+// impassable terrain.
+func (gs *GameState) BuildStructure(playerIndex int, instance CardInstance, card *Card, pos Point) *Structure {
+	if card == nil || card.BuildingStats == nil || gs.TerrainRulesAt(pos.Row, pos.Col).Impassable {
+		return nil
+	}
+	st := gs.AddStructure(playerIndex, BuildingCardStructure, *card.BuildingStats, pos)
+	if st == nil {
+		return nil
+	}
+	st.CardID = card.ID
+	st.CardInstanceID = instance.InstanceID
+	return st
+}
+
+// placeStarterStructures puts each player's Tower and Barracks on their back
+// row as documented: the Tower two columns left of the command center's column
+// and the Barracks two columns right of it (columns 3 and 7 for a command
+// center in column 5), pushed clear of a wider footprint. If a default tile is
+// unavailable the structure goes on the nearest free base tile.
+func (gs *GameState) placeStarterStructures() {
+	for _, cc := range gs.CommandCenters {
+		w, h := cc.Footprint.size()
+		backRow := cc.TopLeftRow
+		if cc.TopLeftRow >= gs.BoardRows/2 {
+			backRow = cc.TopLeftRow + h - 1
+		}
+		starters := []struct {
+			buildingType BuildingType
+			pos          Point
+		}{
+			{BuildingTower, Point{Row: backRow, Col: cc.TopLeftCol - 2}},
+			{BuildingBarracks, Point{Row: backRow, Col: cc.TopLeftCol + max(2, w)}},
+		}
+		for _, s := range starters {
+			pos, ok := gs.nearestFreeBaseTile(cc.PlayerIndex, s.pos)
+			if !ok {
+				continue
+			}
+			gs.AddStructure(cc.PlayerIndex, s.buildingType, starterStructureStats[s.buildingType], pos)
+		}
+	}
+}
+
+// nearestFreeBaseTile returns want if it is a free tile in the player's base,
+// otherwise the free base tile closest to it, breaking ties by row then column.
+func (gs *GameState) nearestFreeBaseTile(playerIndex int, want Point) (Point, bool) {
+	var candidates []Point
+	for r := 0; r < gs.BoardRows; r++ {
+		for c := 0; c < gs.BoardCols; c++ {
+			if gs.ZoneAt(playerIndex, r, c) == ZoneBase && !gs.isTileBlocked(r, c) {
+				candidates = append(candidates, Point{Row: r, Col: c})
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return Point{}, false
+	}
+	dist := func(p Point) int { return abs(p.Row-want.Row) + abs(p.Col-want.Col) }
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return dist(candidates[i]) < dist(candidates[j])
+	})
+	return candidates[0], true
+}
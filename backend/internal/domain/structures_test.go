@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestStarterStructuresBesideCommandCenter(t *testing.T) {
+	gs := NewGameState("structure-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+
+	want := map[int]map[BuildingType]Point{
+		0: {BuildingTower: {Row: 11, Col: 3}, BuildingBarracks: {Row: 11, Col: 7}},
+		1: {BuildingTower: {Row: 0, Col: 3}, BuildingBarracks: {Row: 0, Col: 7}},
+	}
+	for playerIndex, byType := range want {
+		structures := gs.PlayerStructures(playerIndex)
+		if len(structures) != 2 {
+			t.Fatalf("Player %d: expected 2 starter structures, got %d", playerIndex, len(structures))
+		}
+		for _, st := range structures {
+			if pos := byType[st.Building.Type]; st.Position != pos {
+				t.Errorf("Player %d %s: expected %+v, got %+v", playerIndex, st.Building.Type, pos, st.Position)
+			}
+			if !gs.IsTileOccupied(st.Position.Row, st.Position.Col) {
+				t.Errorf("Player %d %s should occupy its tile", playerIndex, st.Building.Type)
+			}
+		}
+	}
+}
+
+func TestDestroyedBarracksStopsGoldIncome(t *testing.T) {
+	gs := NewGameState("structure-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0}, {PlayerIndex: 1}}
+
+	var barracks *Structure
+	for _, st := range gs.PlayerStructures(1) {
+		if st.Building.Type == BuildingBarracks {
+			barracks = st
+		}
+	}
+	attacker := placeTestUnit(gs, 0, Point{Row: 0, Col: barracks.Position.Col + 1}, UnitStats{Attack: barracks.Health + barracks.Armor, Health: 5, Range: 1})
+
+	log := NewEventLog(gs.CurrentTurn)
+	resolveCombat(gs, log)
+
+	if gs.StructureAt(barracks.Position.Row, barracks.Position.Col) != nil {
+		t.Fatal("Expected the barracks to be destroyed and removed")
+	}
+	found := false
+	for _, evt := range log.Events {
+		if evt.Type == EventTypeDamage && evt.Data["attackerId"] == attacker.ID {
+			found = evt.Data["targetType"] == "structure" && evt.Data["destroyed"] == true
+		}
+	}
+	if !found {
+		t.Error("Expected a structure damage event marking the barracks destroyed")
+	}
+
+	gs.ProcessResourceGeneration()
+	if gs.PlayerStates[0].ResourceIncome.Gold != 4 {
+		t.Errorf("Player 0 should keep Barracks income, got %d gold", gs.PlayerStates[0].ResourceIncome.Gold)
+	}
+	if gs.PlayerStates[1].ResourceIncome.Gold != 3 {
+		t.Errorf("Player 1 should lose Barracks income, got %d gold", gs.PlayerStates[1].ResourceIncome.Gold)
+	}
+}
+
+func TestTowerShootsClosestEnemyInRange(t *testing.T) {
+	gs := NewGameState("structure-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	near := placeTestUnit(gs, 1, Point{Row: 10, Col: 3}, UnitStats{Health: 5, Range: 1})
+	far := placeTestUnit(gs, 1, Point{Row: 9, Col: 2}, UnitStats{Health: 5, Range: 1})
+
+	resolveCombat(gs, NewEventLog(gs.CurrentTurn))
+
+	// Player 0's tower sits at (11,3) with 2 attack
+	if near.Health != 3 {
+		t.Errorf("Expected the adjacent enemy to take 2 damage, health=%d", near.Health)
+	}
+	if far.Health != 5 {
+		t.Errorf("Expected the farther enemy to be untouched, health=%d", far.Health)
+	}
+}
+
+func TestBuildingCardPlacesStructureWithIncome(t *testing.T) {
+	gs := NewGameState("structure-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	attack, rng := 1, 3
+	card := &Card{
+		ID:            "watchtower",
+		Type:          CardTypeBuilding,
+		GoldCost:      2,
+		BuildingStats: &BuildingStats{Health: 10, Armor: 1, Attack: &attack, Range: &rng, Income: &ResourceGeneration{Gold: 2}},
+	}
+	gs.SetCardCatalog([]*Card{card})
+	gs.PlayerStates = []PlayerBattleState{
+		{PlayerIndex: 0, Hand: []CardInstance{{InstanceID: "wt-1", CardID: "watchtower"}}, Resources: Resources{Gold: 2}},
+		{PlayerIndex: 1},
+	}
+
+	gs.AddPlannedPlay(PlannedPlay{PlayerIndex: 0, CardInstance: "wt-1", CardID: "watchtower", Position: Point{Row: 9, Col: 4}})
+	ExecuteResolutionPhase(gs, ActionQueue{}, ActionQueue{})
+
+	st := gs.StructureAt(9, 4)
+	if st == nil || st.CardInstanceID != "wt-1" || st.Health != 10 || st.Attack != 1 || st.Range != 3 {
+		t.Fatalf("Expected the watchtower built from its card, got %+v", st)
+	}
+	if len(gs.PlayerStates[0].DiscardPile) != 0 {
+		t.Error("Expected the building card to stay with its structure, not in the discard pile")
+	}
+
+	gs.ProcessResourceGeneration()
+	// Command center 3 + starter barracks 1 + watchtower 2
+	if got := gs.PlayerStates[0].ResourceIncome.Gold; got != 6 {
+		t.Errorf("Expected 6 Gold income including the watchtower, got %d", got)
+	}
+
+	gs.DealDamageToStructure(st, st.Health)
+	if gs.StructureAt(9, 4) != nil {
+		t.Fatal("Expected the destroyed watchtower removed")
+	}
+	if pile := gs.PlayerStates[0].DiscardPile; len(pile) != 1 || pile[0].InstanceID != "wt-1" {
+		t.Errorf("Expected the watchtower card in the discard pile, got %v", pile)
+	}
+}
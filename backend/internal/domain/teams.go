@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"sort"
+)
+
+// PlayerCount returns how many players the match is laid out for: one per
+// player or per command center, whichever is more.
+func (gs *GameState) PlayerCount() int {
+	n := len(gs.Players)
+	for _, cc := range gs.CommandCenters {
+		n = max(n, cc.PlayerIndex+1)
+	}
+	return n
+}
+
+// defaultTeams puts every player on a team of their own.
+func defaultTeams(n int) []int {
+	teams := make([]int, n)
+	for i := range teams {
+		teams[i] = i
+	}
+	return teams
+}
+
+// TeamOf returns a player's team. Players without a team assignment play on
+// their own.
+func (gs *GameState) TeamOf(playerIndex int) int {
+	if playerIndex >= 0 && playerIndex < len(gs.Teams) {
+		return gs.Teams[playerIndex]
+	}
+	return playerIndex
+}
+
+// AreAllies reports whether two players are on the same team. A player is
+// always their own ally.
+func (gs *GameState) AreAllies(a, b int) bool {
+	return a == b || gs.TeamOf(a) == gs.TeamOf(b)
+}
+
+// IsEliminated reports whether a player has lost their command center or
+// conceded. An eliminated player no longer takes part in Planning.
+func (gs *GameState) IsEliminated(playerIndex int) bool {
+	if gs.HasConceded(playerIndex) {
+		return true
+	}
+	cc := gs.GetCommandCenter(playerIndex)
+	return cc != nil && cc.IsDestroyed()
+}
+
+// teamsAlive returns the teams that still have a standing command center of a
+// player who has not conceded, in ascending order.
+func (gs *GameState) teamsAlive() []int {
+	seen := map[int]bool{}
+	var teams []int
+	for _, cc := range gs.CommandCenters {
+		team := gs.TeamOf(cc.PlayerIndex)
+		if cc.IsDestroyed() || gs.HasConceded(cc.PlayerIndex) || seen[team] {
+			continue
+		}
+		seen[team] = true
+		teams = append(teams, team)
+	}
+	sort.Ints(teams)
+	return teams
+}
+
+// GetWinningTeam returns the team that won the match: the match result's
+// winner, or else the last team with a standing command center. Returns -1
+// while the match is still on, or on a draw.
+func (gs *GameState) GetWinningTeam() int {
+	if gs.Result != nil {
+		return gs.Result.WinningTeam
+	}
+	if !gs.IsGameOver() {
+		return -1
+	}
+	if alive := gs.teamsAlive(); len(alive) == 1 {
+		return alive[0]
+	}
+	return -1
+}
+
+// GetWinners returns the player indexes on the winning team, or nil if there
+// is no winner yet.
+func (gs *GameState) GetWinners() []int {
+	team := gs.GetWinningTeam()
+	if team < 0 {
+		return nil
+	}
+	var winners []int
+	for i := 0; i < gs.PlayerCount(); i++ {
+		if gs.TeamOf(i) == team {
+			winners = append(winners, i)
+		}
+	}
+	return winners
+}
+
+// resetPlayerChoices clears every player's lock, pending actions and planned
+// plays for a new round.
+func (gs *GameState) resetPlayerChoices() {
+	gs.PlayerChoicesLocked = map[int]bool{}
+	gs.PendingActions = map[int]ActionQueue{}
+	gs.PlannedPlays = map[int][]PlannedPlay{}
+	for i := 0; i < gs.PlayerCount(); i++ {
+		gs.PlayerChoicesLocked[i] = false
+		gs.PendingActions[i] = ActionQueue{}
+		gs.PlannedPlays[i] = []PlannedPlay{}
+	}
+}
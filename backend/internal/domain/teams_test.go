@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"testing"
+)
+
+func fourPlayers() []Player {
+	return []Player{{ID: "p1"}, {ID: "p2"}, {ID: "p3"}, {ID: "p4"}}
+}
+
+// twoVsTwoMap seats players 0 and 2 on the bottom side against 1 and 3 on top.
+func twoVsTwoMap() *MapDefinition {
+	cc := func(player, row, col int) MapStructure {
+		return MapStructure{PlayerIndex: player, Type: BuildingCommandCenter, Row: row, Col: col, Footprint: Footprint{Width: 2, Height: 2}}
+	}
+	return &MapDefinition{
+		ID:         "2v2",
+		Rows:       12,
+		Cols:       16,
+		Structures: []MapStructure{cc(0, 10, 3), cc(1, 0, 3), cc(2, 10, 10), cc(3, 0, 10)},
+		Teams:      []int{0, 1, 0, 1},
+	}
+}
+
+func TestFourPlayerDefaultLayout(t *testing.T) {
+	gs := NewGameState("ffa-test", fourPlayers(), 12, 16)
+
+	if len(gs.CommandCenters) != 4 {
+		t.Fatalf("Expected a command center per player, got %d", len(gs.CommandCenters))
+	}
+	for i := 0; i < 4; i++ {
+		cc := gs.GetCommandCenter(i)
+		if cc == nil {
+			t.Fatalf("Expected a command center for player %d", i)
+		}
+		if bottom := cc.TopLeftRow >= gs.BoardRows/2; bottom != (i%2 == 0) {
+			t.Errorf("Expected even players at the bottom and odd ones at the top, player %d is at row %d", i, cc.TopLeftRow)
+		}
+		if gs.TeamOf(i) != i {
+			t.Errorf("Expected player %d on their own team, got %d", i, gs.TeamOf(i))
+		}
+		if _, ok := gs.PlayerChoicesLocked[i]; !ok {
+			t.Errorf("Expected a lock entry for player %d", i)
+		}
+		if len(gs.PlayerStructures(i)) != 2 {
+			t.Errorf("Expected starter structures for player %d, got %d", i, len(gs.PlayerStructures(i)))
+		}
+	}
+
+	// Each bottom player owns the half of the bottom base nearest them
+	if gs.ZoneAt(0, 11, 3) != ZoneBase || gs.ZoneAt(0, 11, 12) != ZoneEnemyBase {
+		t.Errorf("Expected player 0's base on the left of the bottom side, got %q and %q", gs.ZoneAt(0, 11, 3), gs.ZoneAt(0, 11, 12))
+	}
+	if gs.ZoneAt(2, 11, 12) != ZoneBase || gs.ZoneAt(2, 11, 3) != ZoneEnemyBase {
+		t.Errorf("Expected player 2's base on the right of the bottom side, got %q and %q", gs.ZoneAt(2, 11, 12), gs.ZoneAt(2, 11, 3))
+	}
+	if d := gs.forwardDirection(2); d.Row != -1 {
+		t.Errorf("Expected bottom-side player 2 to advance up the board, got %+v", d)
+	}
+}
+
+func TestFourPlayerRoundBookkeeping(t *testing.T) {
+	gs := NewGameState("ffa-test", fourPlayers(), 12, 16)
+	gs.AddPlannedPlay(PlannedPlay{PlayerIndex: 3, CardInstance: "c-1", CardID: "x", Position: Point{Row: 1, Col: 12}})
+	gs.PendingActions[3] = ActionQueue{{PlayerIndex: 3, Type: ActionTypeActivateAbility}}
+
+	for i := 0; i < 3; i++ {
+		gs.LockPlayerChoice(i)
+	}
+	if gs.AreAllPlayersLocked() {
+		t.Fatal("Expected the game to wait on player 3")
+	}
+	// An eliminated player is not waited on
+	gs.DealDamageToCommandCenter(3, 1000)
+	if !gs.AreAllPlayersLocked() {
+		t.Error("Expected an eliminated player not to hold up Planning")
+	}
+
+	gs.AdvanceTurn()
+	for i := 0; i < 4; i++ {
+		if gs.IsPlayerLocked(i) {
+			t.Errorf("Expected player %d's lock to be reset", i)
+		}
+		if len(gs.PendingActions[i]) != 0 || len(gs.PlannedPlays[i]) != 0 {
+			t.Errorf("Expected player %d's actions and plays to be cleared", i)
+		}
+	}
+
+	gs.AddPlannedPlay(PlannedPlay{PlayerIndex: 2, CardInstance: "c-2", CardID: "x", Position: Point{Row: 11, Col: 12}})
+	gs.ClearPlannedPlays()
+	if len(gs.PlannedPlays) != 4 || len(gs.PlannedPlays[2]) != 0 {
+		t.Errorf("Expected every player's planned plays to be cleared, got %v", gs.PlannedPlays)
+	}
+}
+
+func TestFreeForAllLastCommandCenterStandingWins(t *testing.T) {
+	gs := NewGameState("ffa-test", fourPlayers(), 12, 16)
+	gs.StartGame()
+
+	for _, loser := range []int{1, 3} {
+		gs.DealDamageToCommandCenter(loser, 1000)
+		if gs.CheckMatchEnd() != nil || gs.Status == GameStatusFinished {
+			t.Fatalf("Expected the match to go on after player %d fell", loser)
+		}
+	}
+	if gs.GetWinner() != -1 {
+		t.Errorf("Expected no winner yet, got %d", gs.GetWinner())
+	}
+
+	gs.DealDamageToCommandCenter(0, 1000)
+	if gs.CheckMatchEnd() == nil || gs.Status != GameStatusFinished {
+		t.Fatal("Expected the match to end with one command center left")
+	}
+	if gs.GetWinner() != 2 || gs.GetWinningTeam() != 2 {
+		t.Errorf("Expected player 2 to win, got winner %d team %d", gs.GetWinner(), gs.GetWinningTeam())
+	}
+}
+
+func TestFreeForAllPriorityTokenSkipsEliminatedPlayers(t *testing.T) {
+	gs := NewGameState("ffa-test", fourPlayers(), 12, 16)
+	gs.StartGame()
+	gs.DealDamageToCommandCenter(1, 1000)
+	gs.Concede(2)
+
+	gs.PriorityPlayer = 0
+	gs.passPriority()
+	if gs.PriorityPlayer != 3 {
+		t.Errorf("Expected the token to skip the fallen and conceded players to 3, got %d", gs.PriorityPlayer)
+	}
+	gs.passPriority()
+	if gs.PriorityPlayer != 0 {
+		t.Errorf("Expected the token to wrap back to player 0, got %d", gs.PriorityPlayer)
+	}
+}
+
+func TestFreeForAllUnitsHuntEnemiesOnTheirOwnSide(t *testing.T) {
+	gs := NewGameState("ffa-test", fourPlayers(), 12, 16)
+	target := gs.GetCommandCenter(2)
+	runner := placeTestUnit(gs, 0, Point{Row: 6, Col: target.TopLeftCol - 4}, UnitStats{Attack: 1, Health: 3, Speed: 2, Range: 1})
+	if d := gs.heading(runner, runner.Position); d != gs.forwardDirection(0) {
+		t.Fatalf("Expected units to march down their lane while an enemy stands across the board, got %+v", d)
+	}
+
+	// With the far side cleared, player 0 goes after player 2 on its own side
+	gs.DealDamageToCommandCenter(1, 1000)
+	gs.DealDamageToCommandCenter(3, 1000)
+	inLine := placeTestUnit(gs, 0, Point{Row: target.TopLeftRow - 3, Col: target.TopLeftCol}, UnitStats{Attack: 1, Health: 3, Speed: 1, Range: 1})
+	resolveMovement(gs, NewEventLog(gs.CurrentTurn))
+
+	if want := (Point{Row: 6, Col: target.TopLeftCol - 2}); runner.Position != want {
+		t.Errorf("Expected the unit to head sideways toward player 2 to %+v, got %+v", want, runner.Position)
+	}
+	if want := (Point{Row: target.TopLeftRow - 2, Col: target.TopLeftCol}); inLine.Position != want {
+		t.Errorf("Expected the unit in line with player 2 to head for it to %+v, got %+v", want, inLine.Position)
+	}
+
+	// A unit heading sideways fights enemies along its row
+	enemy := placeTestUnit(gs, 2, Point{Row: 6, Col: target.TopLeftCol - 1}, UnitStats{Attack: 1, Health: 3, Range: 1})
+	if hit := selectCombatTarget(gs, runner); hit == nil || hit.Target != enemy {
+		t.Errorf("Expected the unit to target the enemy ahead in its row, got %+v", hit)
+	}
+}
+
+func TestTeamsShareVictory(t *testing.T) {
+	gs := NewGameStateFromMap("teams-test", fourPlayers(), twoVsTwoMap(), 1)
+
+	gs.DealDamageToCommandCenter(0, 1000)
+	if gs.IsGameOver() {
+		t.Fatal("Expected the match to go on while player 0's ally stands")
+	}
+	gs.DealDamageToCommandCenter(1, 1000)
+	gs.DealDamageToCommandCenter(3, 1000)
+	if !gs.IsGameOver() {
+		t.Fatal("Expected the match to end once a team has no command center left")
+	}
+	winners := gs.GetWinners()
+	if gs.GetWinningTeam() != 0 || len(winners) != 2 || winners[0] != 0 || winners[1] != 2 {
+		t.Errorf("Expected team 0 (players 0 and 2) to win, got team %d players %v", gs.GetWinningTeam(), winners)
+	}
+	if gs.GetWinner() != 0 {
+		t.Errorf("Expected GetWinner to report the lowest winning player, got %d", gs.GetWinner())
+	}
+}
+
+func TestAlliesDoNotTargetEachOther(t *testing.T) {
+	gs := NewGameStateFromMap("teams-test", fourPlayers(), twoVsTwoMap(), 1)
+	gs.PlayerStates = []PlayerBattleState{{PlayerIndex: 0}, {PlayerIndex: 1}, {PlayerIndex: 2}, {PlayerIndex: 3}}
+
+	if !gs.AreAllies(0, 2) || gs.AreAllies(0, 1) {
+		t.Fatal("Expected players 0 and 2 to be allies against 1 and 3")
+	}
+	// An ally's base is neither the player's own nor an enemy base
+	if z := gs.ZoneAt(0, 11, 12); z != ZoneNeutral {
+		t.Errorf("Expected the ally's base to read as neutral, got %q", z)
+	}
+
+	a := placeTestUnit(gs, 0, Point{Row: 6, Col: 7}, UnitStats{Attack: 2, Health: 3, Range: 1})
+	ally := placeTestUnit(gs, 2, Point{Row: 5, Col: 7}, UnitStats{Attack: 2, Health: 3, Range: 1})
+	if hit := selectCombatTarget(gs, a); hit != nil {
+		t.Errorf("Expected no target for a unit facing only an ally, got %+v", hit)
+	}
+
+	enemy := placeTestUnit(gs, 3, Point{Row: 7, Col: 7}, UnitStats{Attack: 2, Health: 3, Range: 1})
+	hit := selectCombatTarget(gs, a)
+	if hit == nil || hit.Target != enemy {
+		t.Errorf("Expected the enemy unit to be targeted, got %+v", hit)
+	}
+	if hit := selectCombatTarget(gs, ally); hit != nil && hit.Target == a {
+		t.Error("Expected an ally never to be targeted")
+	}
+}
+
+func TestTeammatesShareAurasRallyAndOrders(t *testing.T) {
+	gs := NewGameStateFromMap("teams-test", fourPlayers(), twoVsTwoMap(), 1)
+	buffer := &Card{
+		ID:        "goblin_buffer",
+		Name:      "Goblin Buffer",
+		Type:      CardTypeUnit,
+		UnitStats: &UnitStats{Attack: 1, Health: 3, Range: 1},
+		Aura:      &AuraEffect{Range: 1, Tribe: "Goblin", Attack: 1},
+	}
+	goblin := &Card{ID: "goblin", Name: "Goblin", Type: CardTypeUnit, UnitStats: &UnitStats{Attack: 1, Health: 1, Range: 1}}
+	gs.SetCardCatalog([]*Card{buffer, goblin})
+
+	src := gs.SpawnUnit(0, NewCardInstance(buffer.ID), buffer, Point{Row: 6, Col: 7})
+	teammate := gs.SpawnUnit(2, NewCardInstance(goblin.ID), goblin, Point{Row: 6, Col: 8})
+	enemy := gs.SpawnUnit(1, NewCardInstance(goblin.ID), goblin, Point{Row: 6, Col: 6})
+	refreshAuras(gs)
+	if teammate.Attack != 2 || enemy.Attack != 1 {
+		t.Errorf("Expected the aura to buff the teammate only, got teammate=%d enemy=%d", teammate.Attack, enemy.Attack)
+	}
+
+	log := NewEventLog(gs.CurrentTurn)
+	rally(&abilityContext{gs: gs, log: log, step: "normal", unit: src, kw: Keyword{ID: KeywordRally, Value: 1}, data: map[string]any{}})
+	if !gs.HasStatus(string(teammate.ID), StatusAttack) || gs.HasStatus(string(enemy.ID), StatusAttack) {
+		t.Error("Expected Rally to reach the adjacent teammate and not the enemy")
+	}
+
+	order := &Card{ID: "hold_the_line", Type: CardTypeOrder, OrderEffect: &OrderEffect{TargetType: OrderTargetFriendlyUnit}}
+	if reason := gs.validateOrderTarget(0, order, 6, 8); reason != "" {
+		t.Errorf("Expected a teammate's unit to be a valid order target, got %q", reason)
+	}
+	if reason := gs.validateOrderTarget(0, order, 6, 6); reason != PlacementInvalidTarget {
+		t.Errorf("Expected an enemy unit to be rejected as an order target, got %q", reason)
+	}
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"math/rand"
+)
+
+// Terrain is the ground type of a board tile. The names match the client's
+// tile sprites.
+type Terrain string
+
+const (
+	TerrainGrass    Terrain = "grass"
+	TerrainStone    Terrain = "stone"
+	TerrainWater    Terrain = "water"
+	TerrainDesert   Terrain = "desert"
+	TerrainForest   Terrain = "forest"
+	TerrainMountain Terrain = "mountain"
+)
+
+// TerrainRules is how a terrain type affects play.
+type TerrainRules struct {
+	// Impassable tiles cannot be entered or deployed on except by Flying
+	// units, and nothing can be built on them
+	Impassable bool
+	// Armor is granted to units standing on the tile
+	Armor int
+	// StopsMovement ends a ground unit's movement for the round once it enters
+	// the tile
+	StopsMovement bool
+}
+
+// terrainRules lists the terrain types that do something. Grass and stone are
+// open ground.
+var terrainRules = map[Terrain]TerrainRules{
+	TerrainWater:    {Impassable: true},
+	TerrainMountain: {Impassable: true},
+	TerrainForest:   {Armor: 1},
+	TerrainDesert:   {StopsMovement: true},
+}
+
+// knownTerrain reports whether t is a terrain type the client can draw.
+func knownTerrain(t Terrain) bool {
+	switch t {
+	case TerrainGrass, TerrainStone, TerrainWater, TerrainDesert, TerrainForest, TerrainMountain:
+		return true
+	}
+	return false
+}
+
+// MapTerrain describes a map's terrain: every tile starts as Fill (grass if
+// unset), Generate scatters features across the neutral rows from the match
+// seed, and Tiles are then painted on top in order.
+type MapTerrain struct {
+	Fill     Terrain           `json:"fill,omitempty"`
+	Generate bool              `json:"generate,omitempty"`
+	Tiles    []TerrainOverride `json:"tiles,omitempty"`
+}
+
+// TerrainOverride sets the terrain of a rectangle of tiles.
+type TerrainOverride struct {
+	Row       int       `json:"row"`
+	Col       int       `json:"col"`
+	Footprint Footprint `json:"footprint"`
+	Terrain   Terrain   `json:"terrain"`
+}
+
+// TerrainAt returns the terrain of a tile. Tiles without terrain data read as grass.
+func (gs *GameState) TerrainAt(row, col int) Terrain {
+	if row < 0 || row >= len(gs.Terrain) || col < 0 || col >= len(gs.Terrain[row]) {
+		return TerrainGrass
+	}
+	return gs.Terrain[row][col]
+}
+
+// TerrainRulesAt returns the rules of a tile's terrain.
+func (gs *GameState) TerrainRulesAt(row, col int) TerrainRules {
+	return terrainRules[gs.TerrainAt(row, col)]
+}
+
+// canEnter reports whether terrain lets the unit onto the tile.
+func (gs *GameState) canEnter(u *Unit, row, col int) bool {
+	return !gs.TerrainRulesAt(row, col).Impassable || u.HasKeyword(KeywordFlying)
+}
+
+// newTerrainGrid returns a rows x cols grid of fill.
+func newTerrainGrid(rows, cols int, fill Terrain) [][]Terrain {
+	grid := make([][]Terrain, rows)
+	for r := range grid {
+		grid[r] = make([]Terrain, cols)
+		for c := range grid[r] {
+			grid[r][c] = fill
+		}
+	}
+	return grid
+}
+
+// buildTerrain lays out the board's terrain from the map's terrain spec and
+// the match seed. It runs once, when the match is created.
+func (gs *GameState) buildTerrain(spec *MapTerrain) {
+	fill := TerrainGrass
+	if spec != nil && spec.Fill != "" {
+		fill = spec.Fill
+	}
+	gs.Terrain = newTerrainGrid(gs.BoardRows, gs.BoardCols, fill)
+	if spec == nil {
+		return
+	}
+	if spec.Generate {
+		gs.generateTerrain()
+	}
+	for _, t := range spec.Tiles {
+		for _, p := range t.Footprint.tiles(Point{Row: t.Row, Col: t.Col}) {
+			if gs.InBounds(p.Row, p.Col) {
+				gs.Terrain[p.Row][p.Col] = t.Terrain
+			}
+		}
+	}
+}
+
+// generateTerrain scatters terrain features across the neutral rows. The
+// layout is mirrored through the board's centre so both sides face the same
+// ground, and it uses its own stream derived from the seed so the match's
+// random stream is left untouched. Ground units march straight down their
+// column, so any impassable tile would close a lane; generation only places
+// passable features and leaves water and mountains to hand-placed map tiles.
+func (gs *GameState) generateTerrain() {
+	rng := rand.New(rand.NewSource(gs.Seed))
+	depth := min(baseZoneRows, gs.BoardRows/2)
+	for r := depth; r < gs.BoardRows-depth; r++ {
+		for c := 0; c < gs.BoardCols; c++ {
+			mr, mc := gs.BoardRows-1-r, gs.BoardCols-1-c
+			// Each mirrored pair is rolled once, from the earlier tile
+			if mr < r || (mr == r && mc < c) {
+				continue
+			}
+			t := TerrainGrass
+			switch v := rng.Intn(100); {
+			case v < 12:
+				t = TerrainForest
+			case v < 18:
+				t = TerrainDesert
+			case v < 24:
+				t = TerrainStone
+			}
+			gs.Terrain[r][c] = t
+			gs.Terrain[mr][mc] = t
+		}
+	}
+}
+
+// terrainModifiers adds the terrain layer to every unit's modifier stack from
+// the tile it stands on.
+func terrainModifiers(gs *GameState) {
+	for _, u := range gs.Units {
+		u.Modifiers = withoutModifiers(u.Modifiers, ModifierTerrain, "")
+		if !u.IsAlive() {
+			continue
+		}
+		terrain := gs.TerrainAt(u.Position.Row, u.Position.Col)
+		if rules := terrainRules[terrain]; rules.Armor != 0 {
+			u.Modifiers = append(u.Modifiers, StatModifier{
+				Layer:    ModifierTerrain,
+				SourceID: string(terrain),
+				Armor:    rules.Armor,
+			})
+		}
+	}
+}
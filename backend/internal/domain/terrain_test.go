@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImpassableTerrainBlocksGroundUnitsNotFlying(t *testing.T) {
+	gs := NewGameState("terrain-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.Terrain[7][3] = TerrainWater
+	gs.Terrain[7][4] = TerrainMountain
+	ground := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 2, Speed: 2, Range: 1})
+	flyer := placeTestUnit(gs, 0, Point{Row: 8, Col: 4}, UnitStats{Attack: 1, Health: 2, Speed: 2, Range: 1})
+	flyer.Keywords = []Keyword{{ID: KeywordFlying}}
+
+	resolveMovement(gs, NewEventLog(gs.CurrentTurn))
+
+	if ground.Position != (Point{Row: 8, Col: 3}) {
+		t.Errorf("Expected water to stop the ground unit, got %+v", ground.Position)
+	}
+	if flyer.Position != (Point{Row: 6, Col: 4}) {
+		t.Errorf("Expected the flying unit to cross the mountain, got %+v", flyer.Position)
+	}
+}
+
+func TestDesertStopsMovementForTheRound(t *testing.T) {
+	gs := NewGameState("terrain-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.Terrain[7][3] = TerrainDesert
+	u := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 2, Speed: 3, Range: 1})
+
+	resolveMovement(gs, NewEventLog(gs.CurrentTurn))
+
+	if u.Position != (Point{Row: 7, Col: 3}) {
+		t.Errorf("Expected the unit to stop on entering the desert, got %+v", u.Position)
+	}
+}
+
+func TestForestGrantsArmorWhileStandingInIt(t *testing.T) {
+	gs := NewGameState("terrain-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.Terrain[8][3] = TerrainForest
+	u := placeTestUnit(gs, 0, Point{Row: 8, Col: 3}, UnitStats{Attack: 1, Health: 2, Speed: 1, Range: 1})
+	refreshAuras(gs)
+
+	if u.Armor != 1 {
+		t.Fatalf("Expected +1 Armor in the forest, got %d", u.Armor)
+	}
+
+	resolveMovement(gs, NewEventLog(gs.CurrentTurn))
+	if u.Position.Row != 7 || u.Armor != 0 {
+		t.Errorf("Expected the forest Armor to go once the unit leaves, got row=%d armor=%d", u.Position.Row, u.Armor)
+	}
+}
+
+func TestPlacementRejectsImpassableTerrain(t *testing.T) {
+	gs := NewGameState("terrain-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	gs.Terrain[9][4] = TerrainWater
+	unit := &Card{ID: "u", Type: CardTypeUnit, UnitStats: &UnitStats{Health: 1}}
+	flyer := &Card{ID: "f", Type: CardTypeUnit, UnitStats: &UnitStats{Health: 1}, Abilities: []string{"Flying"}}
+	building := &Card{ID: "b", Type: CardTypeBuilding, BuildingStats: &BuildingStats{Health: 5}}
+
+	if reason := gs.ValidatePlacement(0, unit, 9, 4); reason != PlacementImpassable {
+		t.Errorf("Expected a ground unit on water to be rejected, got %q", reason)
+	}
+	if reason := gs.ValidatePlacement(0, flyer, 9, 4); reason != "" {
+		t.Errorf("Expected a flying unit on water to be allowed, got %q", reason)
+	}
+	if reason := gs.ValidatePlacement(0, building, 9, 4); reason != PlacementImpassable {
+		t.Errorf("Expected a building on water to be rejected, got %q", reason)
+	}
+	if u := gs.SpawnUnit(0, NewCardInstance(unit.ID), unit, Point{Row: 9, Col: 4}); u != nil {
+		t.Error("Expected a ground unit not to spawn on water")
+	}
+}
+
+func TestGeneratedTerrainFollowsSeed(t *testing.T) {
+	m := &MapDefinition{
+		ID: "generated", Rows: 12, Cols: 12,
+		Structures: []MapStructure{
+			{PlayerIndex: 0, Type: BuildingCommandCenter, Row: 10, Col: 5, Footprint: Footprint{Width: 2, Height: 2}},
+			{PlayerIndex: 1, Type: BuildingCommandCenter, Row: 0, Col: 5, Footprint: Footprint{Width: 2, Height: 2}},
+		},
+		Terrain: &MapTerrain{Generate: true, Tiles: []TerrainOverride{{Row: 5, Col: 0, Terrain: TerrainStone}}},
+	}
+	a := NewGameStateFromMap("a", []Player{{ID: "p1"}, {ID: "p2"}}, m, 7)
+	b := NewGameStateFromMap("b", []Player{{ID: "p1"}, {ID: "p2"}}, m, 7)
+	if !reflect.DeepEqual(a.Terrain, b.Terrain) {
+		t.Fatal("Expected the same seed to generate the same terrain")
+	}
+
+	features := 0
+	for r := 0; r < a.BoardRows; r++ {
+		for c := 0; c < a.BoardCols; c++ {
+			terrain := a.TerrainAt(r, c)
+			if (r < baseZoneRows || r >= a.BoardRows-baseZoneRows) && terrain != TerrainGrass {
+				t.Errorf("Expected base rows to stay grass, got %s at (%d,%d)", terrain, r, c)
+			}
+			if r == 5 && c == 0 {
+				continue
+			}
+			if mirror := a.TerrainAt(a.BoardRows-1-r, a.BoardCols-1-c); mirror != terrain && !(r == 6 && c == 11) {
+				t.Errorf("Expected terrain mirrored through the centre at (%d,%d)", r, c)
+			}
+			if terrain != TerrainGrass {
+				features++
+			}
+		}
+	}
+	if features == 0 {
+		t.Error("Expected generation to place some terrain features")
+	}
+	if a.TerrainAt(5, 0) != TerrainStone {
+		t.Error("Expected map terrain tiles to be painted over generated terrain")
+	}
+}
+
+func TestSetSeedLeavesMapTerrainInPlace(t *testing.T) {
+	m := &MapDefinition{
+		ID: "generated", Rows: 12, Cols: 12,
+		Structures: []MapStructure{
+			{PlayerIndex: 0, Type: BuildingCommandCenter, Row: 10, Col: 5},
+			{PlayerIndex: 1, Type: BuildingCommandCenter, Row: 0, Col: 5},
+		},
+		Terrain: &MapTerrain{Generate: true},
+	}
+	gs := NewGameStateFromMap("seeded", []Player{{ID: "p1"}, {ID: "p2"}}, m, 7)
+	want := NewGameStateFromMap("fresh", []Player{{ID: "p1"}, {ID: "p2"}}, m, 7).Terrain
+
+	gs.SetSeed(8)
+	if gs.Seed != 8 || !reflect.DeepEqual(gs.Terrain, want) {
+		t.Error("Expected terrain to stay as laid out from the construction seed")
+	}
+}
+
+func TestGeneratedTerrainKeepsEveryLaneOpen(t *testing.T) {
+	m := &MapDefinition{
+		ID: "generated", Rows: 13, Cols: 11,
+		Structures: []MapStructure{
+			{PlayerIndex: 0, Type: BuildingCommandCenter, Row: 12, Col: 5, Footprint: Footprint{Width: 1, Height: 1}},
+			{PlayerIndex: 1, Type: BuildingCommandCenter, Row: 0, Col: 5, Footprint: Footprint{Width: 1, Height: 1}},
+		},
+		Terrain: &MapTerrain{Generate: true},
+	}
+	for seed := int64(1); seed <= 50; seed++ {
+		gs := NewGameStateFromMap("lanes", []Player{{ID: "p1"}, {ID: "p2"}}, m, seed)
+		for r := 0; r < gs.BoardRows; r++ {
+			for c := 0; c < gs.BoardCols; c++ {
+				if gs.TerrainRulesAt(r, c).Impassable {
+					t.Fatalf("seed %d: expected no generated impassable terrain, got %s closing lane %d at row %d", seed, gs.TerrainAt(r, c), c, r)
+				}
+			}
+		}
+	}
+}
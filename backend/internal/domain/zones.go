@@ -0,0 +1,194 @@
+package domain
+
+// Zone classifies a board tile from one player's point of view.
+type Zone string
+
+const (
+	// ZoneBase is the player's own back rows, excluding the side buffers.
+	ZoneBase Zone = "base"
+	// ZoneEnemyBase is an opponent's base.
+	ZoneEnemyBase Zone = "enemy_base"
+	// ZoneSideBuffer is the outer columns of either side's back rows. It is not
+	// part of any base.
+	ZoneSideBuffer Zone = "side_buffer"
+	// ZoneNeutral is the middle of the board where engagements occur.
+	ZoneNeutral Zone = "neutral"
+)
+
+const (
+	// baseZoneRows is how many back rows make up each side's base.
+	baseZoneRows = 3
+	// sideBufferCols is how many columns on each edge are side buffers.
+	sideBufferCols = 2
+)
+
+// PlayerZones is one player's zone map. Deployment marks the tiles the player
+// may place units on by default, which is their base unless a card says
+// otherwise.
+type PlayerZones struct {
+	PlayerIndex int      `json:"playerIndex"`
+	Tiles       [][]Zone `json:"tiles"`      // [row][col]
+	Deployment  [][]bool `json:"deployment"` // [row][col]
+}
+
+// computeDefaultZones builds every player's zone map from the board size. Each
+// side's base is the back rows nearest its command centers, restricted to the
+// central columns; the outer columns of those rows are side buffers. When a
+// side has several command centers, each base column belongs to the nearest
+// one. An ally's base is neutral ground rather than an enemy base.
+func computeDefaultZones(rows, cols int, commandCenters []*CommandCenter, allied func(a, b int) bool) []PlayerZones {
+	depth := min(baseZoneRows, rows/2)
+	buffer := min(sideBufferCols, (cols-1)/2)
+
+	// bottomSide reports whether a player's command center sits in the lower half
+	bottomSide := func(cc *CommandCenter) bool {
+		return cc.TopLeftRow >= rows/2
+	}
+	// baseOwner returns the command center whose base a column of one side's
+	// back rows belongs to, or nil if no command center stands on that side
+	baseOwner := func(bottom bool, col int) *CommandCenter {
+		var owner *CommandCenter
+		best := 0
+		for _, cc := range commandCenters {
+			if bottomSide(cc) != bottom {
+				continue
+			}
+			w, _ := cc.Footprint.size()
+			d := max(max(cc.TopLeftCol-col, col-(cc.TopLeftCol+w-1)), 0)
+			if owner == nil || d < best {
+				owner = cc
+				best = d
+			}
+		}
+		return owner
+	}
+
+	zones := make([]PlayerZones, 0, len(commandCenters))
+	for _, own := range commandCenters {
+		pz := PlayerZones{
+			PlayerIndex: own.PlayerIndex,
+			Tiles:       make([][]Zone, rows),
+			Deployment:  make([][]bool, rows),
+		}
+		for r := 0; r < rows; r++ {
+			pz.Tiles[r] = make([]Zone, cols)
+			pz.Deployment[r] = make([]bool, cols)
+			inBottom := r >= rows-depth
+			inTop := r < depth
+			for c := 0; c < cols; c++ {
+				zone := ZoneNeutral
+				if inBottom || inTop {
+					owner := baseOwner(inBottom, c)
+					switch {
+					case c < buffer || c >= cols-buffer:
+						zone = ZoneSideBuffer
+					case owner == nil:
+					case owner == own:
+						zone = ZoneBase
+					case !allied(own.PlayerIndex, owner.PlayerIndex):
+						zone = ZoneEnemyBase
+					}
+				}
+				pz.Tiles[r][c] = zone
+				pz.Deployment[r][c] = zone == ZoneBase
+			}
+		}
+		zones = append(zones, pz)
+	}
+	return zones
+}
+
+// ZoneAt returns the zone of a tile from the given player's point of view.
+// Tiles off the board, or players without a zone map, read as neutral.
+func (gs *GameState) ZoneAt(playerIndex, row, col int) Zone {
+	pz := gs.playerZones(playerIndex)
+	if pz == nil || !gs.InBounds(row, col) || row >= len(pz.Tiles) || col >= len(pz.Tiles[row]) {
+		return ZoneNeutral
+	}
+	return pz.Tiles[row][col]
+}
+
+// InDeploymentZone reports whether the player may deploy on the tile by default.
+func (gs *GameState) InDeploymentZone(playerIndex, row, col int) bool {
+	pz := gs.playerZones(playerIndex)
+	if pz == nil || !gs.InBounds(row, col) || row >= len(pz.Deployment) || col >= len(pz.Deployment[row]) {
+		return false
+	}
+	return pz.Deployment[row][col]
+}
+
+func (gs *GameState) playerZones(playerIndex int) *PlayerZones {
+	for i := range gs.Zones {
+		if gs.Zones[i].PlayerIndex == playerIndex {
+			return &gs.Zones[i]
+		}
+	}
+	return nil
+}
+
+// Placement validation reasons reported to clients.
+const (
+	PlacementOutOfBounds = "out_of_bounds"
+	PlacementOccupied    = "occupied"
+	PlacementIllegal     = "illegal_placement"
+	// PlacementImpassable is a tile whose terrain the card cannot be put on
+	PlacementImpassable = "impassable_terrain"
+	// PlacementInvalidTarget is an order aimed at something it cannot target
+	PlacementInvalidTarget = "invalid_target"
+)
+
+// ValidatePlacement checks whether a player may stage card on the tile and
+// returns the rejection reason, or "" if the placement is legal. Cards that put
+// something on the board (units, heroes and buildings) need an empty tile in
+// the player's deployment zone; a card with Summon may instead go anywhere
+// outside an enemy base. Impassable terrain only takes Flying units. Orders
+// need no free tile but must target the player's own unit, or any tile of a
+// lane. Spells may target any tile.
+func (gs *GameState) ValidatePlacement(playerIndex int, card *Card, row, col int) string {
+	if !gs.InBounds(row, col) {
+		return PlacementOutOfBounds
+	}
+	if card != nil && card.IsOrder() {
+		return gs.validateOrderTarget(playerIndex, card, row, col)
+	}
+	if card == nil || !(card.IsUnit() || card.IsHero() || card.IsBuilding()) {
+		return ""
+	}
+	if gs.IsTileOccupied(row, col) {
+		return PlacementOccupied
+	}
+	if gs.TerrainRulesAt(row, col).Impassable && (card.IsBuilding() || !cardHasKeyword(card, KeywordFlying)) {
+		return PlacementImpassable
+	}
+	if gs.InDeploymentZone(playerIndex, row, col) {
+		return ""
+	}
+	if cardHasKeyword(card, KeywordSummon) && gs.ZoneAt(playerIndex, row, col) != ZoneEnemyBase {
+		return ""
+	}
+	return PlacementIllegal
+}
+
+// validateOrderTarget checks an order's target tile against its OrderEffect.
+func (gs *GameState) validateOrderTarget(playerIndex int, card *Card, row, col int) string {
+	if card.OrderEffect == nil {
+		return ""
+	}
+	switch card.OrderEffect.TargetType {
+	case OrderTargetFriendlyUnit:
+		if u := gs.UnitAt(row, col); u == nil || !gs.AreAllies(u.PlayerIndex, playerIndex) {
+			return PlacementInvalidTarget
+		}
+	}
+	return ""
+}
+
+// cardHasKeyword reports whether any of the card's abilities parses to the keyword.
+func cardHasKeyword(card *Card, id KeywordID) bool {
+	for _, a := range card.Abilities {
+		if kw, err := ParseAbility(a); err == nil && kw.ID == id {
+			return true
+		}
+	}
+	return false
+}
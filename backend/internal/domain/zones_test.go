@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestDefaultZonesFollowDesignDoc(t *testing.T) {
+	// 13 rows x 11 cols is the board described in the game design doc
+	gs := NewGameState("zone-test", []Player{{ID: "p1"}, {ID: "p2"}}, 13, 11)
+
+	cases := []struct {
+		player   int
+		row, col int
+		want     Zone
+	}{
+		{0, 12, 5, ZoneBase},
+		{0, 10, 2, ZoneBase},
+		{0, 10, 8, ZoneBase},
+		{0, 11, 1, ZoneSideBuffer},
+		{0, 11, 9, ZoneSideBuffer},
+		{0, 9, 5, ZoneNeutral},
+		{0, 3, 0, ZoneNeutral},
+		{0, 2, 5, ZoneEnemyBase},
+		{0, 0, 10, ZoneSideBuffer},
+		{1, 0, 5, ZoneBase},
+		{1, 12, 5, ZoneEnemyBase},
+	}
+	for _, c := range cases {
+		if got := gs.ZoneAt(c.player, c.row, c.col); got != c.want {
+			t.Errorf("player %d tile (%d,%d): expected %s, got %s", c.player, c.row, c.col, c.want, got)
+		}
+	}
+	if !gs.InDeploymentZone(0, 10, 4) || gs.InDeploymentZone(0, 10, 0) || gs.InDeploymentZone(1, 10, 4) {
+		t.Error("Deployment zone should default to the player's own base")
+	}
+}
+
+func TestValidatePlacement(t *testing.T) {
+	gs := NewGameState("zone-test", []Player{{ID: "p1"}, {ID: "p2"}}, 12, 12)
+	grunt := &Card{ID: "grunt", Type: CardTypeUnit, UnitStats: &UnitStats{Health: 1}, Abilities: []string{"Melee"}}
+	summoned := &Card{ID: "imp", Type: CardTypeUnit, UnitStats: &UnitStats{Health: 1}, Abilities: []string{"Summon"}}
+	bolt := &Card{ID: "bolt", Type: CardTypeSpell}
+
+	cases := []struct {
+		name     string
+		card     *Card
+		row, col int
+		want     string
+	}{
+		{"unit in own base", grunt, 9, 3, ""},
+		{"unit in neutral zone", grunt, 6, 3, PlacementIllegal},
+		{"unit in enemy base", grunt, 1, 3, PlacementIllegal},
+		{"unit on side buffer", grunt, 10, 0, PlacementIllegal},
+		{"unit on command center", grunt, 10, 5, PlacementOccupied},
+		{"summon in neutral zone", summoned, 6, 3, ""},
+		{"summon in enemy base", summoned, 1, 3, PlacementIllegal},
+		{"spell in enemy base", bolt, 1, 3, ""},
+		{"off the board", grunt, 12, 3, PlacementOutOfBounds},
+	}
+	for _, c := range cases {
+		if got := gs.ValidatePlacement(0, c.card, c.row, c.col); got != c.want {
+			t.Errorf("%s: expected %q, got %q", c.name, c.want, got)
+		}
+	}
+}
@@ -23,6 +23,7 @@ func setupTestAPI() *api {
 		gameRepo: repository.NewInMemoryGameRepository(log),
 		cardRepo: repository.NewInMemoryCardRepository(log),
 		deckRepo: repository.NewInMemoryDeckRepository(log),
+		mapRepo:  repository.NewInMemoryMapRepository(log),
 		cfg:      config.Config{},
 		log:      log,
 	}
@@ -0,0 +1,51 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"kitbash/backend/internal/domain"
+)
+
+// GetAllMaps handles GET /api/maps - returns all map definitions
+func (a *api) GetAllMaps(w http.ResponseWriter, r *http.Request) {
+	maps, err := a.mapRepo.GetAllMaps(r.Context())
+	if err != nil {
+		a.log.Error("Failed to get all maps", "error", err)
+		http.Error(w, "Failed to retrieve maps", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"maps":  maps,
+		"count": len(maps),
+	}); err != nil {
+		a.log.Error("Failed to encode maps response", "error", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// GetMap handles GET /api/maps/{mapId} - returns a specific map definition
+func (a *api) GetMap(w http.ResponseWriter, r *http.Request) {
+	mapID := domain.MapID(chi.URLParam(r, "mapId"))
+	if mapID == "" {
+		http.Error(w, "Map ID is required", http.StatusBadRequest)
+		return
+	}
+
+	m, err := a.mapRepo.GetMap(r.Context(), mapID)
+	if err != nil {
+		a.log.Error("Failed to get map", "mapID", mapID, "error", err)
+		http.Error(w, "Map not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(m); err != nil {
+		a.log.Error("Failed to encode map response", "error", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
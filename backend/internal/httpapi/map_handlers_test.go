@@ -0,0 +1,74 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllMaps(t *testing.T) {
+	api := setupTestAPI()
+
+	req := httptest.NewRequest("GET", "/api/maps", nil)
+	w := httptest.NewRecorder()
+
+	api.GetAllMaps(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	maps, ok := response["maps"].([]interface{})
+	require.True(t, ok)
+	ids := make([]string, 0, len(maps))
+	for _, m := range maps {
+		ids = append(ids, m.(map[string]interface{})["id"].(string))
+	}
+	assert.Contains(t, ids, "standard_12x12")
+	assert.Contains(t, ids, "classic_11x13")
+}
+
+func TestGetMap(t *testing.T) {
+	api := setupTestAPI()
+
+	req := httptest.NewRequest("GET", "/api/maps/classic_11x13", nil)
+	w := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("mapId", "classic_11x13")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	api.GetMap(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var m map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &m)
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(13), m["rows"])
+	assert.Equal(t, float64(11), m["cols"])
+}
+
+func TestGetMapNotFound(t *testing.T) {
+	api := setupTestAPI()
+
+	req := httptest.NewRequest("GET", "/api/maps/nowhere", nil)
+	w := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("mapId", "nowhere")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	api.GetMap(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -22,6 +22,7 @@ type api struct {
 	gameRepo repository.GameRepository
 	cardRepo domain.CardRepository
 	deckRepo domain.DeckRepository
+	mapRepo  domain.MapRepository
 	cfg      config.Config
 	log      *logger.Logger
 }
@@ -47,6 +48,7 @@ func NewRouter(cfg config.Config) http.Handler {
 		gameRepo: repository.NewInMemoryGameRepository(log),
 		cardRepo: repository.NewInMemoryCardRepository(log),
 		deckRepo: repository.NewInMemoryDeckRepository(log),
+		mapRepo:  repository.NewInMemoryMapRepository(log),
 		cfg:      cfg,
 		log:      log,
 	}
@@ -61,7 +63,7 @@ func NewRouter(cfg config.Config) http.Handler {
 	}
 
 	hub := ws.NewHub(log, cfg)
-    gameHub := ws.NewGameHubWithRepos(a.gameRepo, a.deckRepo, a.cardRepo, log, cfg)
+    gameHub := ws.NewGameHubWithRepos(a.gameRepo, a.deckRepo, a.cardRepo, a.mapRepo, log, cfg)
 
 	// GET /healthz: liveness probe for container/orchestrator.
 	r.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {
@@ -126,6 +128,14 @@ func NewRouter(cfg config.Config) http.Handler {
 			// GET /api/decks/color/{color}: get decks by color
 			r.Get("/color/{color}", a.GetDecksByColor)
 		})
+
+		// Map endpoints
+		r.Route("/maps", func(r chi.Router) {
+			// GET /api/maps: get all map definitions
+			r.Get("/", a.GetAllMaps)
+			// GET /api/maps/{mapId}: get a specific map definition
+			r.Get("/{mapId}", a.GetMap)
+		})
 	})
 
 	// WebSocket endpoints for real-time events
@@ -439,6 +449,7 @@ func (a *api) handleDealDamage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	destroyed := gameState.DealDamageToCommandCenter(req.PlayerIndex, req.Damage)
+	gameState.CheckMatchEnd()
 
 	if err := a.gameRepo.Update(r.Context(), gameState); err != nil {
 		a.log.LogError(r.Context(), err, "Failed to update game state")
@@ -30,14 +30,31 @@ func NewInMemoryCardRepository(log *logger.Logger) *InMemoryCardRepository {
 	return repo
 }
 
-// seedDefaultCards populates the repository with initial card data.
+// seedDefaultCards populates the repository with initial card data. A seed
+// card whose abilities do not validate is logged and left out.
 func (r *InMemoryCardRepository) seedDefaultCards() {
+	seeded := 0
+	for _, card := range defaultCards() {
+		if err := card.ValidateAbilities(); err != nil {
+			r.log.Error("Skipping invalid seed card", "id", card.ID, "error", err)
+			continue
+		}
+		r.warnUnsupportedAbilities(card)
+		r.cards[card.ID] = card
+		seeded++
+	}
+	
+	r.log.Info("Seeded card repository with default cards", "count", seeded)
+}
+
+// defaultCards returns the built-in card catalog.
+func defaultCards() []*domain.Card {
 	now := time.Now()
 	
 	// Helper function to create string pointers
 	strPtr := func(s string) *string { return &s }
 	
-	defaultCards := []*domain.Card{
+	return []*domain.Card{
 		// Red Pawn - Goblin (according to docs: 2/2, Armor 0, Melee)
 		{
 			ID:          "red_pawn_goblin",
@@ -113,6 +130,10 @@ func (r *InMemoryCardRepository) seedDefaultCards() {
 			SpellEffect: &domain.SpellEffect{
 				TargetType: "unit",
 				Effect:     "Deal 2 damage to target unit. Heal 2 health.",
+				Operations: []domain.EffectOp{
+					{Op: domain.EffectOpDamage, Amount: 2},
+					{Op: domain.EffectOpHeal, Target: domain.EffectTargetOwnCommandCenter, Amount: 2},
+				},
 			},
 			Abilities:  []string{},
 			FlavorText: strPtr("Life force flows from enemy to caster."),
@@ -135,6 +156,9 @@ func (r *InMemoryCardRepository) seedDefaultCards() {
 				Speed:    1,
 				Range:    1,
 				Cooldown: 2,
+				Passives: []domain.HeroPassive{
+					{Type: domain.HeroPassiveStartingGold, Value: 2},
+				},
 			},
 			Abilities:  []string{"Melee", "Berserk"},
 			FlavorText: strPtr("Roaring fury given form."),
@@ -149,6 +173,13 @@ func (r *InMemoryCardRepository) seedDefaultCards() {
 			ManaCost:    1,
 			Type:        domain.CardTypeOrder,
 			Color:       domain.CardColorRed,
+			OrderEffect: &domain.OrderEffect{
+				TargetType: domain.OrderTargetFriendlyUnit,
+				Effect:     "Give a friendly unit +2 ATK this round.",
+				Operations: []domain.EffectOp{
+					{Op: domain.EffectOpBuff, Attack: 2, Duration: domain.EffectDurationRound},
+				},
+			},
 			Abilities:   []string{"Buff"},
 			FlavorText:  strPtr("Let the fury take you."),
 			CreatedAt:   now,
@@ -229,6 +260,13 @@ func (r *InMemoryCardRepository) seedDefaultCards() {
 				Speed:  1,
 				Range:  1,
 			},
+			DeathEffect: &domain.SpellEffect{
+				TargetType: "ground",
+				Effect:     "Deal 2 damage to adjacent units.",
+				Operations: []domain.EffectOp{
+					{Op: domain.EffectOpAreaAdjacent, Amount: 2},
+				},
+			},
 			Abilities:  []string{"Melee", "Deathrattle"},
 			FlavorText: strPtr("Boom goes the goblin."),
 			CreatedAt:  now,
@@ -249,6 +287,7 @@ func (r *InMemoryCardRepository) seedDefaultCards() {
 				Speed:  1,
 				Range:  1,
 			},
+			Aura:       &domain.AuraEffect{Range: 1, Tribe: "Goblin", Attack: 1},
 			Abilities:  []string{"Melee", "Aura"},
 			FlavorText: strPtr("He yells till they hit harder."),
 			CreatedAt:  now,
@@ -257,11 +296,18 @@ func (r *InMemoryCardRepository) seedDefaultCards() {
 		{
 			ID:          "red_order_rush",
 			Name:        "Rush",
-			Description: "Target friendly unit may move and attack immediately.",
+			Description: "Target friendly unit gets +1 SPD this round.",
 			GoldCost:    0,
 			ManaCost:    1,
 			Type:        domain.CardTypeOrder,
 			Color:       domain.CardColorRed,
+			OrderEffect: &domain.OrderEffect{
+				TargetType: domain.OrderTargetFriendlyUnit,
+				Effect:     "Give a friendly unit +1 SPD this round.",
+				Operations: []domain.EffectOp{
+					{Op: domain.EffectOpBuff, Speed: 1, Duration: domain.EffectDurationRound},
+				},
+			},
 			Abilities:   []string{"Order"},
 			FlavorText:  strPtr("Go! Go! Go!"),
 			CreatedAt:   now,
@@ -298,6 +344,9 @@ func (r *InMemoryCardRepository) seedDefaultCards() {
 			SpellEffect: &domain.SpellEffect{
 				TargetType: "unit",
 				Effect:     "+2 ATK and +1 SPD this round",
+				Operations: []domain.EffectOp{
+					{Op: domain.EffectOpBuff, Attack: 2, Speed: 1, Duration: domain.EffectDurationRound},
+				},
 			},
 			Abilities:  []string{"Buff"},
 			FlavorText: strPtr("See red. Strike true."),
@@ -320,6 +369,10 @@ func (r *InMemoryCardRepository) seedDefaultCards() {
 				Speed:    1,
 				Range:    1,
 				Cooldown: 2,
+				Passives: []domain.HeroPassive{
+					{Type: domain.HeroPassiveHandLimit, Value: 1},
+					{Type: domain.HeroPassiveTowerMana, Value: 1},
+				},
 			},
 			Abilities:  []string{"Melee", "Haunt"},
 			FlavorText: strPtr("Memories bind. Agonies guide."),
@@ -337,6 +390,12 @@ func (r *InMemoryCardRepository) seedDefaultCards() {
 			SpellEffect: &domain.SpellEffect{
 				TargetType: "unit",
 				Effect:     "Deal 2 damage. If it dies, draw 1.",
+				Operations: []domain.EffectOp{
+					{Op: domain.EffectOpDamage, Amount: 2},
+					{Op: domain.EffectOpIfDies, Then: []domain.EffectOp{
+						{Op: domain.EffectOpDraw, Target: domain.EffectTargetCaster, Amount: 1},
+					}},
+				},
 			},
 			Abilities:  []string{},
 			FlavorText: strPtr("Suffering teaches best."),
@@ -414,6 +473,9 @@ func (r *InMemoryCardRepository) seedDefaultCards() {
 			SpellEffect: &domain.SpellEffect{
 				TargetType: "lane",
 				Effect:     "Deal 1 damage to all units in target lane",
+				Operations: []domain.EffectOp{
+					{Op: domain.EffectOpAreaLane, Amount: 1},
+				},
 			},
 			Abilities:  []string{"Area"},
 			FlavorText: strPtr("The air itself rebels."),
@@ -451,6 +513,9 @@ func (r *InMemoryCardRepository) seedDefaultCards() {
 			SpellEffect: &domain.SpellEffect{
 				TargetType: "unit",
 				Effect:     "Deal 3 damage",
+				Operations: []domain.EffectOp{
+					{Op: domain.EffectOpDamage, Amount: 3},
+				},
 			},
 			Abilities:  []string{},
 			FlavorText: strPtr("From below, a sudden end."),
@@ -498,12 +563,14 @@ func (r *InMemoryCardRepository) seedDefaultCards() {
 			UpdatedAt:  now,
 		},
 	}
-	
-	for _, card := range defaultCards {
-		r.cards[card.ID] = card
+}
+
+// warnUnsupportedAbilities logs the card's keywords the engine has no rules for,
+// so cards relying on them are visible rather than silently doing nothing.
+func (r *InMemoryCardRepository) warnUnsupportedAbilities(card *domain.Card) {
+	if unsupported := card.UnsupportedAbilities(); len(unsupported) > 0 {
+		r.log.Warn("Card has keywords without engine support", "id", card.ID, "keywords", unsupported)
 	}
-	
-	r.log.Info("Seeded card repository with default cards", "count", len(defaultCards))
 }
 
 // GetCard retrieves a card by its ID.
@@ -575,6 +642,10 @@ func (r *InMemoryCardRepository) CreateCard(ctx context.Context, card *domain.Ca
 	if _, exists := r.cards[card.ID]; exists {
 		return fmt.Errorf("card with ID %s already exists", card.ID)
 	}
+	if err := card.ValidateAbilities(); err != nil {
+		return err
+	}
+	r.warnUnsupportedAbilities(card)
 	
 	now := time.Now()
 	card.CreatedAt = now
@@ -594,6 +665,10 @@ func (r *InMemoryCardRepository) UpdateCard(ctx context.Context, card *domain.Ca
 	if _, exists := r.cards[card.ID]; !exists {
 		return fmt.Errorf("card with ID %s not found", card.ID)
 	}
+	if err := card.ValidateAbilities(); err != nil {
+		return err
+	}
+	r.warnUnsupportedAbilities(card)
 	
 	card.UpdatedAt = time.Now()
 	r.cards[card.ID] = card
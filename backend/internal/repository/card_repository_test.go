@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"testing"
+)
+
+func TestDefaultCardAbilitiesValidate(t *testing.T) {
+	for _, card := range defaultCards() {
+		if err := card.ValidateAbilities(); err != nil {
+			t.Errorf("Seed card %s has invalid abilities: %v", card.ID, err)
+		}
+	}
+}
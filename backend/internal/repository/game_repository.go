@@ -12,6 +12,7 @@ import (
 // GameRepository defines the interface for game state management.
 type GameRepository interface {
 	Create(ctx context.Context, gameID domain.GameID, players []domain.Player, boardRows, boardCols int) (*domain.GameState, error)
+	CreateFromMap(ctx context.Context, gameID domain.GameID, players []domain.Player, m *domain.MapDefinition, seed int64) (*domain.GameState, error)
 	Get(ctx context.Context, gameID domain.GameID) (*domain.GameState, error)
 	Update(ctx context.Context, gameState *domain.GameState) error
 	Delete(ctx context.Context, gameID domain.GameID) error
@@ -53,6 +54,28 @@ func (r *InMemoryGameRepository) Create(ctx context.Context, gameID domain.GameI
 	return gameState, nil
 }
 
+// CreateFromMap creates a new game state laid out from a map definition with
+// the given match seed.
+func (r *InMemoryGameRepository) CreateFromMap(ctx context.Context, gameID domain.GameID, players []domain.Player, m *domain.MapDefinition, seed int64) (*domain.GameState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.games[gameID]; exists {
+		return nil, fmt.Errorf("game with ID %s already exists", gameID)
+	}
+
+	gameState := domain.NewGameStateFromMap(gameID, players, m, seed)
+	r.games[gameID] = gameState
+
+	r.log.WithContext(ctx).Info("Created new game state",
+		"game_id", gameID,
+		"players", len(players),
+		"map_id", m.ID,
+		"board_size", fmt.Sprintf("%dx%d", m.Rows, m.Cols))
+
+	return gameState, nil
+}
+
 // Get retrieves a game state by ID.
 func (r *InMemoryGameRepository) Get(ctx context.Context, gameID domain.GameID) (*domain.GameState, error) {
 	r.mu.RLock()
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+
+	"kitbash/backend/internal/domain"
+	"kitbash/backend/internal/logger"
+)
+
+// mapFiles holds the built-in map definitions, one JSON file per map.
+//
+//go:embed maps/*.json
+var mapFiles embed.FS
+
+// InMemoryMapRepository implements the MapRepository interface using the
+// built-in map definitions.
+type InMemoryMapRepository struct {
+	maps  map[domain.MapID]*domain.MapDefinition
+	mutex sync.RWMutex
+	log   *logger.Logger
+}
+
+// NewInMemoryMapRepository creates a map repository loaded with the built-in
+// maps. Map files that fail to parse are logged and skipped.
+func NewInMemoryMapRepository(log *logger.Logger) *InMemoryMapRepository {
+	repo := &InMemoryMapRepository{
+		maps: make(map[domain.MapID]*domain.MapDefinition),
+		log:  log,
+	}
+	repo.loadBuiltinMaps()
+	return repo
+}
+
+// loadBuiltinMaps parses every embedded map file into the repository.
+func (r *InMemoryMapRepository) loadBuiltinMaps() {
+	entries, err := mapFiles.ReadDir("maps")
+	if err != nil {
+		r.log.Error("Failed to list built-in maps", "error", err)
+		return
+	}
+	for _, entry := range entries {
+		name := path.Join("maps", entry.Name())
+		data, err := mapFiles.ReadFile(name)
+		if err != nil {
+			r.log.Error("Failed to read map file", "file", name, "error", err)
+			continue
+		}
+		m, err := domain.ParseMapDefinition(data)
+		if err != nil {
+			r.log.Error("Failed to parse map file", "file", name, "error", err)
+			continue
+		}
+		r.maps[m.ID] = m
+	}
+	r.log.Info("Loaded built-in maps", "count", len(r.maps))
+}
+
+// GetMap retrieves a map definition by its ID.
+func (r *InMemoryMapRepository) GetMap(ctx context.Context, id domain.MapID) (*domain.MapDefinition, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	m, exists := r.maps[id]
+	if !exists {
+		return nil, fmt.Errorf("map with ID %s not found", id)
+	}
+
+	// Return a copy to prevent external modifications
+	return m.Clone(), nil
+}
+
+// GetAllMaps retrieves copies of all map definitions, ordered by ID.
+func (r *InMemoryMapRepository) GetAllMaps(ctx context.Context) ([]*domain.MapDefinition, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	maps := make([]*domain.MapDefinition, 0, len(r.maps))
+	for _, m := range r.maps {
+		maps = append(maps, m.Clone())
+	}
+	sort.Slice(maps, func(i, j int) bool { return maps[i].ID < maps[j].ID })
+	return maps, nil
+}
@@ -3,8 +3,11 @@ package ws
 import (
 	"context"
 	"encoding/json"
-	"math/rand"
+	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -24,17 +27,24 @@ type GameClient struct {
 	writeMutex sync.Mutex // Protects websocket writes
 }
 
+// cleanupDiscardTimeout is how long players have to choose their Cleanup discards.
+const cleanupDiscardTimeout = 15 * time.Second
+
 // GameHub manages WebSocket connections for game instances.
 type GameHub struct {
 	mu          sync.RWMutex
 	gameRepo    repository.GameRepository
 	cardRepo    domain.CardRepository
 	deckRepo    domain.DeckRepository
+	mapRepo     domain.MapRepository
 	clients     map[domain.GameID]map[*websocket.Conn]*GameClient
 	upgrader    websocket.Upgrader
 	log         *logger.Logger
 	cfg         config.Config
 	phaseTimers map[domain.GameID]*time.Timer
+	// cleanedUp records the turn whose Cleanup has already run for each game,
+	// so a late timer or manual advance cannot run it twice
+	cleanedUp map[domain.GameID]int
 }
 
 // NewGameHub creates a new game hub with game state management.
@@ -51,14 +61,16 @@ func NewGameHub(gameRepo repository.GameRepository, log *logger.Logger, cfg conf
 		log:         log,
 		cfg:         cfg,
 		phaseTimers: make(map[domain.GameID]*time.Timer),
+		cleanedUp:   make(map[domain.GameID]int),
 	}
 }
 
-// NewGameHubWithRepos creates a new game hub with both game and deck repositories.
-func NewGameHubWithRepos(gameRepo repository.GameRepository, deckRepo domain.DeckRepository, cardRepo domain.CardRepository, log *logger.Logger, cfg config.Config) *GameHub {
+// NewGameHubWithRepos creates a new game hub with game, deck, card and map repositories.
+func NewGameHubWithRepos(gameRepo repository.GameRepository, deckRepo domain.DeckRepository, cardRepo domain.CardRepository, mapRepo domain.MapRepository, log *logger.Logger, cfg config.Config) *GameHub {
 	hub := NewGameHub(gameRepo, log, cfg)
 	hub.deckRepo = deckRepo
 	hub.cardRepo = cardRepo
+	hub.mapRepo = mapRepo
 	return hub
 }
 
@@ -75,8 +87,19 @@ func (h *GameHub) HandleGameWS(w http.ResponseWriter, r *http.Request, gameID st
 		return
 	}
 
+	// An optional seed query parameter makes a new match reproducible
+	var seed *int64
+	if raw := r.URL.Query().Get("seed"); raw != "" {
+		if v, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			seed = &v
+		}
+	}
+
+	// An optional map query parameter picks the board layout of a new match
+	mapID := domain.MapID(r.URL.Query().Get("map"))
+
 	// Get or create game state
-	gameState, err := h.getOrCreateGameState(r.Context(), gameID)
+	gameState, err := h.getOrCreateGameState(r.Context(), gameID, seed, mapID)
 	if err != nil {
 		h.log.LogError(r.Context(), err, "Failed to get/create game state")
 		conn.Close()
@@ -157,24 +180,63 @@ func (h *GameHub) removeClient(client *GameClient) {
 	}
 }
 
+// lookupMap returns the requested map definition, or the configured default
+// map when none is requested. Returns nil when there is no map repository or
+// the map is unknown, in which case the default board layout is used.
+func (h *GameHub) lookupMap(ctx context.Context, mapID domain.MapID) *domain.MapDefinition {
+	if mapID == "" {
+		mapID = domain.MapID(h.cfg.DefaultMap)
+	}
+	if h.mapRepo == nil || mapID == "" {
+		return nil
+	}
+	m, err := h.mapRepo.GetMap(ctx, mapID)
+	if err != nil {
+		h.log.LogError(ctx, err, "Unknown map requested, using the default layout", "map_id", mapID)
+		return nil
+	}
+	return m
+}
+
 // getOrCreateGameState retrieves existing game state or creates a new one.
-func (h *GameHub) getOrCreateGameState(ctx context.Context, gameID string) (*domain.GameState, error) {
+// A non-nil seed fixes the new match's seed and mapID picks its map, falling
+// back to the configured default map; both are ignored for existing games.
+func (h *GameHub) getOrCreateGameState(ctx context.Context, gameID string, seed *int64, mapID domain.MapID) (*domain.GameState, error) {
 	// Try to get existing game state
 	gameState, err := h.gameRepo.Get(ctx, domain.GameID(gameID))
 	if err == nil {
 		return gameState, nil
 	}
 
-	// Create new game state with default players
-	players := []domain.Player{
-		{ID: "player", Name: "Player"},
-		{ID: "cpu", Name: "CPU"},
+	// Create new game state with default players: one seat per command
+	// center on the map, all but the first played by the CPU. Map games are
+	// seeded as they are built, since their terrain follows the seed.
+	if m := h.lookupMap(ctx, mapID); m != nil {
+		matchSeed := time.Now().UnixNano()
+		if seed != nil {
+			matchSeed = *seed
+		}
+		gameState, err = h.gameRepo.CreateFromMap(ctx, domain.GameID(gameID), defaultPlayers(m.PlayerCount()), m, matchSeed)
+	} else {
+		gameState, err = h.gameRepo.Create(ctx, domain.GameID(gameID), defaultPlayers(2), h.cfg.BoardRows, h.cfg.BoardCols)
+		if err == nil && seed != nil {
+			gameState.SetSeed(*seed)
+		}
 	}
-
-	gameState, err = h.gameRepo.Create(ctx, domain.GameID(gameID), players, h.cfg.BoardRows, h.cfg.BoardCols)
 	if err != nil {
 		return nil, err
 	}
+	gameState.TurnLimit = h.cfg.TurnLimit
+
+	// Load the card catalog so resolution can turn plays into board state
+	if h.cardRepo != nil {
+		cards, err := h.cardRepo.GetAllCards(ctx)
+		if err != nil {
+			h.log.LogError(ctx, err, "Failed to load card catalog")
+		} else {
+			gameState.SetCardCatalog(cards)
+		}
+	}
 
 	// Assign decks and draw initial hands if deck repository is available
 	if h.deckRepo != nil {
@@ -190,14 +252,34 @@ func (h *GameHub) getOrCreateGameState(ctx context.Context, gameID string) (*dom
 	}
 
 	// Start the phase progression
-	go func() {
-		time.Sleep(2 * time.Second)
+	h.schedulePhase(gameState.ID, 2*time.Second, func() {
 		h.advanceToPhase(context.Background(), gameState.ID, domain.PhasePlanning)
-	}()
+	})
 
 	return gameState, nil
 }
 
+// defaultPlayers seats n players: the local player followed by CPU opponents.
+func defaultPlayers(n int) []domain.Player {
+	players := []domain.Player{{ID: "player", Name: "Player"}}
+	for i := 1; i < n; i++ {
+		if i == 1 {
+			players = append(players, domain.Player{ID: "cpu", Name: "CPU"})
+			continue
+		}
+		players = append(players, domain.Player{
+			ID:   domain.PlayerID(fmt.Sprintf("cpu-%d", i)),
+			Name: fmt.Sprintf("CPU %d", i),
+		})
+	}
+	return players
+}
+
+// isCPUPlayer reports whether a seat is played by the CPU.
+func isCPUPlayer(p domain.Player) bool {
+	return strings.HasPrefix(string(p.ID), "cpu") || strings.HasPrefix(p.Name, "CPU")
+}
+
 // WriteJSON safely writes a JSON message to the client's websocket connection.
 func (c *GameClient) WriteJSON(v interface{}) error {
 	c.writeMutex.Lock()
@@ -277,12 +359,18 @@ func (h *GameHub) handleGameMessage(ctx context.Context, client *GameClient, msg
 		return h.handleAdvancePhase(ctx, client)
 	case "validate_target":
 		return h.handleValidateTarget(ctx, client, message)
+	case "preview_moves":
+		return h.handlePreviewMoves(ctx, client, message)
 	case "stage_play_card":
 		return h.handleStagePlayCard(ctx, client, message)
 	case "unplay_card":
 		return h.handleUnplayCard(ctx, client, message)
 	case "reset_planned_plays":
 		return h.handleResetPlannedPlays(ctx, client, message)
+	case "cleanup_discard":
+		return h.handleCleanupDiscard(ctx, client, message)
+	case "concede":
+		return h.handleConcede(ctx, client, message)
 	default:
 		h.log.WithContext(ctx).Debug("Unknown message type", "type", msgType)
 	}
@@ -290,8 +378,38 @@ func (h *GameHub) handleGameMessage(ctx context.Context, client *GameClient, msg
 	return nil
 }
 
+// handlePreviewMoves replies with the route a unit would take during this round's
+// movement step and every tile it could end its move on, using the same pathfinding
+// as resolution.
+func (h *GameHub) handlePreviewMoves(ctx context.Context, client *GameClient, message map[string]interface{}) error {
+	gameState, err := h.gameRepo.Get(ctx, client.GameID)
+	if err != nil {
+		return err
+	}
+
+	unitID, _ := message["unitId"].(string)
+	resp := map[string]interface{}{
+		"type":   "move_preview",
+		"unitId": unitID,
+		"valid":  false,
+	}
+
+	preview, ok := gameState.PreviewMovement(domain.UnitID(unitID))
+	if !ok {
+		resp["reason"] = "unknown_unit"
+		return client.WriteJSON(resp)
+	}
+	resp["valid"] = true
+	resp["path"] = preview.Path
+	resp["reachable"] = preview.Reachable
+	return client.WriteJSON(resp)
+}
+
 // handleValidateTarget checks if a proposed target tile is valid for the given card.
-// Rules: A target is valid unless it is a unit card attempting to be played on an occupied space.
+// Rules: The tile must pass GameState.ValidatePlacement (bounds, occupancy and deployment
+// zones for cards placed on the board; a friendly unit or a lane for orders), and the player
+// must be able to afford the card on top of their other planned plays. For orders the
+// response also carries the order's targetType so the client can highlight the right tiles.
 func (h *GameHub) handleValidateTarget(ctx context.Context, client *GameClient, message map[string]interface{}) error {
 	gameState, err := h.gameRepo.Get(ctx, client.GameID)
 	if err != nil {
@@ -324,8 +442,8 @@ func (h *GameHub) handleValidateTarget(ctx context.Context, client *GameClient,
 	}
 
 	// Bounds check
-	if row < 0 || col < 0 || row >= gameState.BoardRows || col >= gameState.BoardCols {
-		resp["reason"] = "out_of_bounds"
+	if !gameState.InBounds(row, col) {
+		resp["reason"] = domain.PlacementOutOfBounds
 		return client.WriteJSON(resp)
 	}
 
@@ -334,14 +452,11 @@ func (h *GameHub) handleValidateTarget(ctx context.Context, client *GameClient,
 	if cardIDStr != "" {
 		cardID = domain.CardID(cardIDStr)
 	} else {
-		// Lookup by instance ID in player's hand
+		// Lookup by instance ID in player's hand or command zone
 		if playerIndex >= 0 && playerIndex < len(gameState.PlayerStates) {
-			for _, inst := range gameState.PlayerStates[playerIndex].Hand {
-				if string(inst.InstanceID) == cardInstanceID {
-					cardID = inst.CardID
-					resp["cardId"] = string(cardID)
-					break
-				}
+			if inst, _, ok := gameState.PlayerStates[playerIndex].FindCard(domain.CardInstanceID(cardInstanceID)); ok {
+				cardID = inst.CardID
+				resp["cardId"] = string(cardID)
 			}
 		}
 	}
@@ -352,21 +467,29 @@ func (h *GameHub) handleValidateTarget(ctx context.Context, client *GameClient,
 		return client.WriteJSON(resp)
 	}
 
-	// If cardRepo is present, get type to check if unit
-	isUnit := false
+	// If cardRepo is present, get the card to check its type and cost
+	var card *domain.Card
 	if h.cardRepo != nil {
-		card, err := h.cardRepo.GetCard(ctx, cardID)
-		if err == nil && card != nil {
-			isUnit = card.IsUnit()
+		if c, err := h.cardRepo.GetCard(ctx, cardID); err == nil && c != nil {
+			card = c
 		}
 	}
 
-	if isUnit && gameState.IsTileOccupied(row, col) {
-		resp["reason"] = "occupied"
+	if card != nil && card.IsOrder() && card.OrderEffect != nil {
+		resp["targetType"] = string(card.OrderEffect.TargetType)
+	}
+
+	if reason := gameState.ValidatePlacement(playerIndex, card, row, col); reason != "" {
+		resp["reason"] = reason
+		return client.WriteJSON(resp)
+	}
+
+	if !gameState.CanAffordPlay(playerIndex, domain.CardInstanceID(cardInstanceID), card) {
+		resp["reason"] = "out_of_resources"
 		return client.WriteJSON(resp)
 	}
 
-	// Valid if not a unit or tile not occupied
+	// Valid if the placement is legal and the card is affordable
 	resp["valid"] = true
 	return client.WriteJSON(resp)
 }
@@ -493,18 +616,11 @@ func (h *GameHub) handleStagePlayCard(ctx context.Context, client *GameClient, m
 		return nil
 	}
 	ps := &gameState.PlayerStates[playerIndex]
-	var cardID domain.CardID
-	found := false
-	for _, inst := range ps.Hand {
-		if string(inst.InstanceID) == cardInstanceID {
-			cardID = inst.CardID
-			found = true
-			break
-		}
-	}
+	inst, inCommandZone, found := ps.FindCard(domain.CardInstanceID(cardInstanceID))
 	if !found {
 		return nil
 	}
+	cardID := inst.CardID
 
 	// Validate target according to rules
 	validateMsg := map[string]interface{}{
@@ -517,21 +633,28 @@ func (h *GameHub) handleStagePlayCard(ctx context.Context, client *GameClient, m
 		"valid":          false,
 	}
 
-	// Bounds check
-	if row < 0 || col < 0 || row >= gameState.BoardRows || col >= gameState.BoardCols {
-		validateMsg["reason"] = "out_of_bounds"
+	// A hero in the command zone can only be staged once its cooldown is over
+	if inCommandZone && ps.HeroCooldown > 0 {
+		validateMsg["reason"] = "hero_on_cooldown"
 		client.WriteJSON(validateMsg)
 		return nil
 	}
 
-	isUnit := false
+	var card *domain.Card
 	if h.cardRepo != nil {
-		if card, err := h.cardRepo.GetCard(ctx, cardID); err == nil && card != nil {
-			isUnit = card.IsUnit()
+		if c, err := h.cardRepo.GetCard(ctx, cardID); err == nil && c != nil {
+			card = c
 		}
 	}
-	if isUnit && gameState.IsTileOccupied(row, col) {
-		validateMsg["reason"] = "occupied"
+	if reason := gameState.ValidatePlacement(playerIndex, card, row, col); reason != "" {
+		validateMsg["reason"] = reason
+		client.WriteJSON(validateMsg)
+		return nil
+	}
+
+	// The card must be affordable together with everything already planned
+	if !gameState.CanAffordPlay(playerIndex, domain.CardInstanceID(cardInstanceID), card) {
+		validateMsg["reason"] = "out_of_resources"
 		client.WriteJSON(validateMsg)
 		return nil
 	}
@@ -574,6 +697,7 @@ func (h *GameHub) handleDealDamage(ctx context.Context, client *GameClient, mess
 	}
 
 	destroyed := gameState.DealDamageToCommandCenter(int(playerIndex), int(damage))
+	result := gameState.CheckMatchEnd()
 
 	if err := h.gameRepo.Update(ctx, gameState); err != nil {
 		return err
@@ -585,6 +709,10 @@ func (h *GameHub) handleDealDamage(ctx context.Context, client *GameClient, mess
 		"damage", int(damage),
 		"destroyed", destroyed)
 
+	if result != nil {
+		h.endMatch(ctx, client.GameID, result)
+	}
+
 	// Broadcast updated game state to all clients
 	return h.broadcastGameState(ctx, client.GameID)
 }
@@ -698,8 +826,25 @@ func (h *GameHub) handleSubmitActions(ctx context.Context, client *GameClient, m
 		}
 	}
 
+	// Activated abilities are checked for ownership, once-per-round use,
+	// targets and Mana before they are queued; rejected ones are reported back
+	submitted := queue
+	queue, rejected := gameState.ValidateActivations(int(playerIndexF), submitted)
+	for index := range submitted {
+		reason, ok := rejected[index]
+		if !ok {
+			continue
+		}
+		client.WriteJSON(map[string]interface{}{
+			"type":        "action_rejected",
+			"playerIndex": int(playerIndexF),
+			"actionIndex": index,
+			"reason":      reason,
+		})
+	}
+
 	if gameState.PendingActions == nil {
-		gameState.PendingActions = map[int]domain.ActionQueue{0: {}, 1: {}}
+		gameState.PendingActions = map[int]domain.ActionQueue{}
 	}
 	gameState.PendingActions[int(playerIndexF)] = queue
 
@@ -777,29 +922,46 @@ func (h *GameHub) assignTestDecksAndHands(ctx context.Context, gs *domain.GameSt
 		return
 	}
 
-	// Choose two decks: randomly for variety
-	rand.Seed(time.Now().UnixNano())
-	var idx0 = 0
-	var idx1 = 1
-	if len(decks) > 1 {
-		idx0 = rand.Intn(len(decks))
-		for {
-			idx1 = rand.Intn(len(decks))
-			if idx1 != idx0 {
-				break
+	// Choose a deck per player: randomly for variety, from the match's random
+	// stream, and a different one for each player while there are enough.
+	// The repository returns decks in no particular order, so sort them first.
+	sort.Slice(decks, func(i, j int) bool { return decks[i].ID < decks[j].ID })
+	rng := gs.Rand()
+	picked := make([]*domain.Deck, gs.PlayerCount())
+	used := map[int]bool{}
+	for i := range picked {
+		idx := i % len(decks)
+		if len(decks) > 1 {
+			for {
+				idx = rng.Intn(len(decks))
+				if !used[idx] || len(used) >= len(decks) {
+					break
+				}
 			}
 		}
+		used[idx] = true
+		picked[i] = decks[idx]
 	}
-	deck0 := decks[idx0]
-	deck1 := decks[idx1%len(decks)]
 
-	gs.PlayerStates = make([]domain.PlayerBattleState, 2)
-	gs.PlayerStates[0] = buildPlayerStateFromDeck(0, deck0)
-	gs.PlayerStates[1] = buildPlayerStateFromDeck(1, deck1)
+	gs.PlayerStates = make([]domain.PlayerBattleState, len(picked))
+	for i, deck := range picked {
+		gs.PlayerStates[i] = buildPlayerStateFromDeck(gs, i, deck)
+	}
 
-	// Draw initial hands (7 cards)
-	drawCardsForPlayer(&gs.PlayerStates[0], 7)
-	drawCardsForPlayer(&gs.PlayerStates[1], 7)
+	// Apply each hero's passive modifiers before the opening draw
+	for i, deck := range picked {
+		if h.cardRepo == nil || deck.HeroCardID == "" {
+			continue
+		}
+		if hero, err := h.cardRepo.GetCard(ctx, deck.HeroCardID); err == nil {
+			gs.ApplyHeroPassives(i, hero)
+		}
+	}
+
+	// Draw initial hands up to each player's hand limit
+	for i := range gs.PlayerStates {
+		drawCardsForPlayer(&gs.PlayerStates[i], gs.PlayerStates[i].HandLimit)
+	}
 }
 
 // handleAdvancePhase manually advances to the next phase (for testing or timeout).
@@ -818,13 +980,8 @@ func (h *GameHub) handleAdvancePhase(ctx context.Context, client *GameClient) er
 	case domain.PhaseRevealResolve:
 		nextPhase = domain.PhaseCleanup
 	case domain.PhaseCleanup:
-		// Start new turn
-		gameState.AdvanceTurn()
-		if err := h.gameRepo.Update(ctx, gameState); err != nil {
-			return err
-		}
-		h.broadcastTurnAdvanced(ctx, client.GameID, gameState.CurrentTurn)
-		return h.advanceToPhase(ctx, client.GameID, domain.PhaseDrawIncome)
+		// Finish cleanup now (auto-picking any outstanding discards) and start a new turn
+		return h.finishCleanup(ctx, client.GameID)
 	default:
 		nextPhase = domain.PhaseDrawIncome
 	}
@@ -839,20 +996,22 @@ func (h *GameHub) advanceToPhase(ctx context.Context, gameID domain.GameID, phas
 		return err
 	}
 
-	// Log current pending discards when advancing phase
-	var p0Discards, p1Discards []domain.CardInstanceID
-	if len(gameState.PlayerStates) > 0 {
-		p0Discards = gameState.PlayerStates[0].PendingDiscards
+	// A finished match never moves on; this also stops any transition that
+	// was already scheduled when it ended
+	if gameState.Status == domain.GameStatusFinished {
+		return nil
 	}
-	if len(gameState.PlayerStates) > 1 {
-		p1Discards = gameState.PlayerStates[1].PendingDiscards
+
+	// Log current pending discards when advancing phase
+	pendingDiscards := make(map[int][]domain.CardInstanceID, len(gameState.PlayerStates))
+	for i, ps := range gameState.PlayerStates {
+		pendingDiscards[i] = ps.PendingDiscards
 	}
 	h.log.WithContext(ctx).Info("Advancing to phase",
 		"game_id", gameID,
 		"from_phase", gameState.CurrentPhase,
 		"to_phase", phase,
-		"player0_pending_discards", p0Discards,
-		"player1_pending_discards", p1Discards)
+		"pending_discards", pendingDiscards)
 
 	gameState.SetPhase(phase)
 
@@ -879,8 +1038,12 @@ func (h *GameHub) advanceToPhase(ctx context.Context, gameID domain.GameID, phas
 		}
 		// Broadcast timeline for upkeep
 		h.broadcastResolutionTimeline(ctx, gameID, upkeepLog)
+		if gameState.Result != nil {
+			h.endMatch(ctx, gameID, gameState.Result)
+			return nil
+		}
 		// Advance to Planning after brief delay
-		time.AfterFunc(1*time.Second, func() {
+		h.schedulePhase(gameID, 1*time.Second, func() {
 			h.advanceToPhase(context.Background(), gameID, domain.PhasePlanning)
 		})
 
@@ -888,8 +1051,8 @@ func (h *GameHub) advanceToPhase(ctx context.Context, gameID domain.GameID, phas
 		// Start 30-second timer for Planning phase
 		h.startPlanningTimer(ctx, gameID)
 
-		// If this is a CPU game, have the CPU immediately discard a random card and lock in
-		// This provides a simple opponent for testing "Play vs CPU"
+		// If this is a CPU game, have each CPU immediately discard a random card and lock in
+		// This provides simple opponents for testing "Play vs CPU"
 		go h.maybeAutoLockCPU(ctx, gameID)
 
 	case domain.PhaseRevealResolve:
@@ -903,10 +1066,12 @@ func (h *GameHub) advanceToPhase(ctx context.Context, gameID domain.GameID, phas
 			}
 		}
 
-		// Resolve actions deterministically
-		p1 := gameState.PendingActions[0]
-		p2 := gameState.PendingActions[1]
-		resolutionLog := domain.ExecuteResolutionPhase(gameState, p1, p2)
+		// Resolve every player's actions deterministically
+		queues := make([]domain.ActionQueue, gameState.PlayerCount())
+		for i := range queues {
+			queues[i] = gameState.PendingActions[i]
+		}
+		resolutionLog := domain.ExecuteResolutionPhase(gameState, queues...)
 
 		// Log discards after resolution
 		for i, ps := range gameState.PlayerStates {
@@ -919,29 +1084,39 @@ func (h *GameHub) advanceToPhase(ctx context.Context, gameID domain.GameID, phas
 		}
 
 		// Clear pending actions after resolution
-		gameState.PendingActions[0] = domain.ActionQueue{}
-		gameState.PendingActions[1] = domain.ActionQueue{}
+		for i := range queues {
+			gameState.PendingActions[i] = domain.ActionQueue{}
+		}
 		if err := h.gameRepo.Update(ctx, gameState); err != nil {
 			return err
 		}
 		// Broadcast timeline for resolution
 		h.broadcastResolutionTimeline(ctx, gameID, resolutionLog)
+		if gameState.Result != nil {
+			h.endMatch(ctx, gameID, gameState.Result)
+			return nil
+		}
 		// Automatically advance to Cleanup shortly
-		time.AfterFunc(500*time.Millisecond, func() {
+		h.schedulePhase(gameID, 500*time.Millisecond, func() {
 			h.advanceToPhase(context.Background(), gameID, domain.PhaseCleanup)
 		})
 
 	case domain.PhaseCleanup:
-		// Next round starts immediately after minimal delay
-		time.AfterFunc(500*time.Millisecond, func() {
-			gs, _ := h.gameRepo.Get(context.Background(), gameID)
-			if gs != nil {
-				gs.AdvanceTurn()
-				h.gameRepo.Update(context.Background(), gs)
-				h.broadcastTurnAdvanced(context.Background(), gameID, gs.CurrentTurn)
-				h.advanceToPhase(context.Background(), gameID, domain.PhaseDrawIncome)
+		// Players over their hand limit choose what to discard; the CPU and
+		// anyone who runs out of time get an automatic pick
+		if gameState.BeginCleanup() {
+			h.autoPickCPUCleanupDiscards(gameState)
+			if err := h.gameRepo.Update(ctx, gameState); err != nil {
+				return err
 			}
-		})
+			if !gameState.CleanupChoicesComplete() {
+				h.startCleanupTimer(ctx, gameID)
+				break
+			}
+		}
+		if err := h.finishCleanup(ctx, gameID); err != nil {
+			return err
+		}
 	}
 
 	// Broadcast updated game state
@@ -975,6 +1150,126 @@ func (h *GameHub) startPlanningTimer(ctx context.Context, gameID domain.GameID)
 	h.mu.Unlock()
 }
 
+// startCleanupTimer gives players cleanupDiscardTimeout to choose their
+// discards before the rest are picked for them.
+func (h *GameHub) startCleanupTimer(ctx context.Context, gameID domain.GameID) {
+	h.mu.Lock()
+	if timer, exists := h.phaseTimers[gameID]; exists {
+		timer.Stop()
+	}
+	timer := time.AfterFunc(cleanupDiscardTimeout, func() {
+		h.log.WithContext(context.Background()).Info("Cleanup discard timer expired",
+			"game_id", gameID)
+		h.finishCleanup(context.Background(), gameID)
+	})
+	h.phaseTimers[gameID] = timer
+	h.mu.Unlock()
+}
+
+// finishCleanup runs the Cleanup phase, broadcasts its events and starts the
+// next round shortly after. Cleanup runs once per turn: the phase stays Cleanup
+// until the next turn starts, so later calls in that window do nothing.
+func (h *GameHub) finishCleanup(ctx context.Context, gameID domain.GameID) error {
+	gameState, err := h.gameRepo.Get(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	// Leave the already scheduled next turn alone if Cleanup has run
+	if !h.claimCleanup(gameID, gameState) {
+		return nil
+	}
+	h.cancelPhaseTimer(gameID)
+
+	cleanupLog := domain.ExecuteCleanupPhase(gameState)
+	if err := h.gameRepo.Update(ctx, gameState); err != nil {
+		return err
+	}
+	h.broadcastResolutionTimeline(ctx, gameID, cleanupLog)
+
+	// Next round starts immediately after minimal delay
+	h.schedulePhase(gameID, 500*time.Millisecond, func() {
+		gs, _ := h.gameRepo.Get(context.Background(), gameID)
+		if gs != nil && gs.Status != domain.GameStatusFinished {
+			gs.AdvanceTurn()
+			h.gameRepo.Update(context.Background(), gs)
+			h.broadcastTurnAdvanced(context.Background(), gameID, gs.CurrentTurn)
+			h.advanceToPhase(context.Background(), gameID, domain.PhaseDrawIncome)
+		}
+	})
+	return h.broadcastGameState(ctx, gameID)
+}
+
+// claimCleanup marks the game's current turn as cleaned up and reports whether
+// this caller is the one that should run Cleanup.
+func (h *GameHub) claimCleanup(gameID domain.GameID, gameState *domain.GameState) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if gameState.CurrentPhase != domain.PhaseCleanup {
+		return false
+	}
+	if turn, done := h.cleanedUp[gameID]; done && turn == gameState.CurrentTurn {
+		return false
+	}
+	h.cleanedUp[gameID] = gameState.CurrentTurn
+	return true
+}
+
+// handleCleanupDiscard records which cards a player discards to get back to
+// their hand limit during Cleanup.
+func (h *GameHub) handleCleanupDiscard(ctx context.Context, client *GameClient, message map[string]interface{}) error {
+	gameState, err := h.gameRepo.Get(ctx, client.GameID)
+	if err != nil {
+		return err
+	}
+	if gameState.CurrentPhase != domain.PhaseCleanup {
+		return nil
+	}
+
+	var playerIndex int
+	if v, ok := message["playerIndex"].(float64); ok {
+		playerIndex = int(v)
+	}
+	var ids []domain.CardInstanceID
+	if cards, ok := message["discardCards"].([]interface{}); ok {
+		for _, card := range cards {
+			if cardStr, ok := card.(string); ok {
+				ids = append(ids, domain.CardInstanceID(cardStr))
+			}
+		}
+	}
+
+	if reason := gameState.ChooseCleanupDiscards(playerIndex, ids); reason != "" {
+		return client.WriteJSON(map[string]interface{}{
+			"type":        "cleanup_discard_rejected",
+			"playerIndex": playerIndex,
+			"reason":      reason,
+		})
+	}
+	if err := h.gameRepo.Update(ctx, gameState); err != nil {
+		return err
+	}
+
+	h.log.WithContext(ctx).Info("Cleanup discards chosen",
+		"game_id", client.GameID,
+		"player_index", playerIndex,
+		"cards", ids)
+
+	if gameState.CleanupChoicesComplete() {
+		return h.finishCleanup(ctx, client.GameID)
+	}
+	return h.broadcastGameState(ctx, client.GameID)
+}
+
+// autoPickCPUCleanupDiscards lets the CPU player's cleanup discards be picked
+// automatically instead of waiting on the timer.
+func (h *GameHub) autoPickCPUCleanupDiscards(gs *domain.GameState) {
+	for i, p := range gs.Players {
+		if isCPUPlayer(p) {
+			gs.AutoPickCleanupDiscards(i)
+		}
+	}
+}
+
 // broadcastResolutionTimeline sends the event log for the round to all clients.
 func (h *GameHub) broadcastResolutionTimeline(ctx context.Context, gameID domain.GameID, log *domain.EventLog) {
 	h.mu.RLock()
@@ -1002,6 +1297,95 @@ func (h *GameHub) broadcastResolutionTimeline(ctx context.Context, gameID domain
 	}
 }
 
+// schedulePhase runs fn after delay as the game's phase timer, replacing any
+// pending one, so cancelPhaseTimer stops every scheduled transition.
+func (h *GameHub) schedulePhase(gameID domain.GameID, delay time.Duration, fn func()) {
+	h.mu.Lock()
+	if timer, exists := h.phaseTimers[gameID]; exists {
+		timer.Stop()
+	}
+	h.phaseTimers[gameID] = time.AfterFunc(delay, fn)
+	h.mu.Unlock()
+}
+
+// handleConcede forfeits the match for a player. If that leaves a single team
+// the match ends; otherwise play goes on without them.
+func (h *GameHub) handleConcede(ctx context.Context, client *GameClient, message map[string]interface{}) error {
+	playerIndex, ok := message["playerIndex"].(float64)
+	if !ok {
+		return nil
+	}
+
+	gameState, err := h.gameRepo.Get(ctx, client.GameID)
+	if err != nil {
+		return err
+	}
+	if gameState.Status == domain.GameStatusFinished {
+		return nil
+	}
+
+	result := gameState.Concede(int(playerIndex))
+	if err := h.gameRepo.Update(ctx, gameState); err != nil {
+		return err
+	}
+
+	h.log.WithContext(ctx).Info("Player conceded",
+		"game_id", client.GameID,
+		"player_index", int(playerIndex),
+		"match_over", result != nil)
+
+	if result != nil {
+		h.endMatch(ctx, client.GameID, result)
+		return nil
+	}
+
+	// The others may only have been waiting on the player who left
+	if gameState.CurrentPhase == domain.PhasePlanning && gameState.AreAllPlayersLocked() {
+		h.cancelPhaseTimer(client.GameID)
+		return h.advanceToPhase(ctx, client.GameID, domain.PhaseRevealResolve)
+	}
+	return h.broadcastGameState(ctx, client.GameID)
+}
+
+// endMatch stops the game's timers and tells all clients the match is over.
+func (h *GameHub) endMatch(ctx context.Context, gameID domain.GameID, result *domain.MatchResult) {
+	h.cancelPhaseTimer(gameID)
+
+	h.log.WithContext(ctx).Info("Match ended",
+		"game_id", gameID,
+		"outcome", result.Outcome,
+		"reason", result.Reason,
+		"winners", result.Winners)
+
+	h.mu.RLock()
+	gameClients, exists := h.clients[gameID]
+	if !exists {
+		h.mu.RUnlock()
+		return
+	}
+	clients := make([]*GameClient, 0, len(gameClients))
+	for _, client := range gameClients {
+		clients = append(clients, client)
+	}
+	h.mu.RUnlock()
+
+	message := map[string]interface{}{
+		"type":   "match_end",
+		"result": result,
+		"reason": result.Reason,
+	}
+
+	for _, client := range clients {
+		if err := client.WriteJSON(message); err != nil {
+			h.log.LogError(ctx, err, "Failed to send match end notification")
+		}
+	}
+
+	if err := h.broadcastGameState(ctx, gameID); err != nil {
+		h.log.LogError(ctx, err, "Failed to broadcast final game state")
+	}
+}
+
 // cancelPhaseTimer cancels the phase timer for a game.
 func (h *GameHub) cancelPhaseTimer(gameID domain.GameID) {
 	h.mu.Lock()
@@ -1039,8 +1423,9 @@ func (h *GameHub) broadcastPhaseChange(ctx context.Context, gameID domain.GameID
 	}
 }
 
-// maybeAutoLockCPU performs a trivial CPU action during Planning: discard one random
-// card (if available) and immediately lock in. This is intended purely for testing.
+// maybeAutoLockCPU performs a trivial action for every CPU player during
+// Planning: discard one random card (if available) and immediately lock in.
+// This is intended purely for testing.
 func (h *GameHub) maybeAutoLockCPU(ctx context.Context, gameID domain.GameID) {
 	gs, err := h.gameRepo.Get(ctx, gameID)
 	if err != nil {
@@ -1052,54 +1437,48 @@ func (h *GameHub) maybeAutoLockCPU(ctx context.Context, gameID domain.GameID) {
 		return
 	}
 
-	// Identify CPU player index. Only proceed if a CPU player exists.
-	foundCPU := false
-	cpuIndex := 1
-	if len(gs.Players) > 0 {
-		for i, p := range gs.Players {
-			if string(p.ID) == "cpu" || p.Name == "CPU" {
-				cpuIndex = i
-				foundCPU = true
-				break
-			}
+	// Identify the CPU players that still need to lock. Only proceed if any exist.
+	var cpuIndexes []int
+	for i, p := range gs.Players {
+		if !isCPUPlayer(p) || gs.IsPlayerLocked(i) || gs.IsEliminated(i) {
+			continue
 		}
+		cpuIndexes = append(cpuIndexes, i)
 	}
-	if !foundCPU {
+	if len(cpuIndexes) == 0 {
 		return
 	}
 
-	// If already locked, nothing to do
-	if gs.PlayerChoicesLocked != nil && gs.PlayerChoicesLocked[cpuIndex] {
-		return
-	}
-
-	// Choose a random card from CPU hand to discard (if any)
-	if cpuIndex >= 0 && cpuIndex < len(gs.PlayerStates) {
-		ps := &gs.PlayerStates[cpuIndex]
-		if len(ps.Hand) > 0 {
-			rand.Seed(time.Now().UnixNano())
-			idx := rand.Intn(len(ps.Hand))
-			discardID := ps.Hand[idx].InstanceID
-			ps.PendingDiscards = append(ps.PendingDiscards, discardID)
-			h.log.WithContext(ctx).Info("CPU queued discard",
-				"game_id", gameID,
-				"player_index", cpuIndex,
-				"card", discardID)
+	for _, cpuIndex := range cpuIndexes {
+		// Choose a random card from CPU hand to discard (if any)
+		if cpuIndex < len(gs.PlayerStates) {
+			ps := &gs.PlayerStates[cpuIndex]
+			if len(ps.Hand) > 0 {
+				idx := gs.Rand().Intn(len(ps.Hand))
+				discardID := ps.Hand[idx].InstanceID
+				ps.PendingDiscards = append(ps.PendingDiscards, discardID)
+				h.log.WithContext(ctx).Info("CPU queued discard",
+					"game_id", gameID,
+					"player_index", cpuIndex,
+					"card", discardID)
+			}
 		}
-	}
 
-	// Lock CPU choice
-	gs.LockPlayerChoice(cpuIndex)
+		// Lock CPU choice
+		gs.LockPlayerChoice(cpuIndex)
+	}
 	if err := h.gameRepo.Update(ctx, gs); err != nil {
 		return
 	}
 
-	h.log.WithContext(ctx).Info("CPU locked choice",
-		"game_id", gameID,
-		"player_index", cpuIndex)
+	for _, cpuIndex := range cpuIndexes {
+		h.log.WithContext(ctx).Info("CPU locked choice",
+			"game_id", gameID,
+			"player_index", cpuIndex)
 
-	// Notify all clients that CPU locked
-	h.broadcastPlayerLocked(ctx, gameID, cpuIndex)
+		// Notify all clients that CPU locked
+		h.broadcastPlayerLocked(ctx, gameID, cpuIndex)
+	}
 
 	// If everyone locked, move to Reveal & Resolve immediately
 	if gs.AreAllPlayersLocked() {
@@ -1113,20 +1492,24 @@ func (h *GameHub) maybeAutoLockCPU(ctx context.Context, gameID domain.GameID) {
 }
 
 // buildPlayerStateFromDeck expands deck entries into a shuffled draw pile and initializes state.
-func buildPlayerStateFromDeck(playerIndex int, deck *domain.Deck) domain.PlayerBattleState {
+// The hero is kept out of the draw pile and starts in the command zone. Instance IDs and
+// the shuffle come from the match's random stream.
+func buildPlayerStateFromDeck(gs *domain.GameState, playerIndex int, deck *domain.Deck) domain.PlayerBattleState {
 	// Expand deck entries to card instances by quantity
 	var drawPile []domain.CardInstance
-	for _, entry := range deck.GetAllCards() {
+	for _, entry := range deck.GetDrawableCards() {
 		for i := 0; i < entry.Quantity; i++ {
 			// Create a unique instance for each card
-			instance := domain.NewCardInstance(entry.CardID)
+			instance := gs.NewCardInstance(entry.CardID)
 			drawPile = append(drawPile, instance)
 		}
 	}
 	// Shuffle drawPile
-	for i := len(drawPile) - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
-		drawPile[i], drawPile[j] = drawPile[j], drawPile[i]
+	gs.ShuffleCards(drawPile)
+
+	var commandZone []domain.CardInstance
+	if deck.HeroCardID != "" {
+		commandZone = append(commandZone, gs.NewCardInstance(deck.HeroCardID))
 	}
 
 	return domain.PlayerBattleState{
@@ -1136,9 +1519,10 @@ func buildPlayerStateFromDeck(playerIndex int, deck *domain.Deck) domain.PlayerB
 		DeckCount:   len(drawPile),
 		DrawPile:    drawPile,
 		DiscardPile: []domain.CardInstance{},
+		CommandZone: commandZone,
 		Resources:   domain.Resources{Gold: 0, Mana: 0}, // Start with no resources
 		ResourceIncome: domain.ResourceGeneration{Gold: 0, Mana: 0}, // Will be calculated from buildings
-		HandLimit:   7,
+		HandLimit:   domain.DefaultHandLimit,
 	}
 }
 
@@ -139,4 +139,101 @@ func TestDiscardFunctionality(t *testing.T) {
 	}
 
 	t.Log("Test completed successfully")
-}
\ No newline at end of file
+}
+
+func TestConcedeEndsMatchAndStopsPhaseLoop(t *testing.T) {
+	ctx := context.Background()
+	log := logger.Default()
+	cfg := config.Config{BoardRows: 12, BoardCols: 12}
+	gameRepo := repository.NewInMemoryGameRepository(log)
+	hub := NewGameHub(gameRepo, log, cfg)
+
+	gameID := domain.GameID("test-concede-game")
+	players := []domain.Player{
+		{ID: "player1", Name: "Player 1"},
+		{ID: "player2", Name: "Player 2"},
+	}
+	gameState, err := gameRepo.Create(ctx, gameID, players, cfg.BoardRows, cfg.BoardCols)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	gameState.StartGame()
+	gameState.SetPhase(domain.PhasePlanning)
+	if err := gameRepo.Update(ctx, gameState); err != nil {
+		t.Fatalf("Failed to update game state: %v", err)
+	}
+	hub.startPlanningTimer(ctx, gameID)
+
+	client := &GameClient{GameID: gameID}
+	if err := hub.handleConcede(ctx, client, map[string]interface{}{"playerIndex": float64(1)}); err != nil {
+		t.Fatalf("Concede failed: %v", err)
+	}
+
+	savedState, err := gameRepo.Get(ctx, gameID)
+	if err != nil {
+		t.Fatalf("Failed to get game state: %v", err)
+	}
+	if savedState.Status != domain.GameStatusFinished || savedState.Result == nil {
+		t.Fatal("Expected the match to be finished with a result")
+	}
+	if savedState.Result.Reason != domain.MatchEndConcede || savedState.GetWinner() != 0 {
+		t.Errorf("Expected player 0 to win by concession, got %+v", savedState.Result)
+	}
+	if _, running := hub.phaseTimers[gameID]; running {
+		t.Error("Expected the phase timer to be stopped")
+	}
+
+	// A finished match no longer advances
+	if err := hub.advanceToPhase(ctx, gameID, domain.PhaseRevealResolve); err != nil {
+		t.Fatalf("Advance failed: %v", err)
+	}
+	savedState, _ = gameRepo.Get(ctx, gameID)
+	if savedState.CurrentPhase != domain.PhasePlanning {
+		t.Errorf("Expected the phase to stay put after the match ended, got %s", savedState.CurrentPhase)
+	}
+}
+
+func TestCleanupRunsOncePerTurn(t *testing.T) {
+	ctx := context.Background()
+	log := logger.Default()
+	cfg := config.Config{BoardRows: 12, BoardCols: 12}
+	gameRepo := repository.NewInMemoryGameRepository(log)
+	hub := NewGameHub(gameRepo, log, cfg)
+
+	gameID := domain.GameID("test-cleanup-game")
+	players := []domain.Player{
+		{ID: "player1", Name: "Player 1"},
+		{ID: "player2", Name: "Player 2"},
+	}
+	gameState, err := gameRepo.Create(ctx, gameID, players, cfg.BoardRows, cfg.BoardCols)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	gameState.StartGame()
+	gameState.SetPhase(domain.PhaseCleanup)
+	hand := make([]domain.CardInstance, 0, 9)
+	for i := 0; i < 9; i++ {
+		hand = append(hand, domain.NewCardInstance("test_card"))
+	}
+	gameState.PlayerStates = []domain.PlayerBattleState{{PlayerIndex: 0, HandLimit: 7, Hand: hand}, {PlayerIndex: 1}}
+	gameState.BeginCleanup()
+	if err := gameRepo.Update(ctx, gameState); err != nil {
+		t.Fatalf("Failed to update game state: %v", err)
+	}
+	defer hub.cancelPhaseTimer(gameID)
+
+	// The cleanup timer and a manual advance both finishing Cleanup
+	for i := 0; i < 2; i++ {
+		if err := hub.finishCleanup(ctx, gameID); err != nil {
+			t.Fatalf("Finish cleanup failed: %v", err)
+		}
+	}
+
+	savedState, _ := gameRepo.Get(ctx, gameID)
+	if got := len(savedState.PlayerStates[0].DiscardPile); got != 2 {
+		t.Errorf("Expected Cleanup to discard down to the limit once, got %d discards", got)
+	}
+	if _, scheduled := hub.phaseTimers[gameID]; !scheduled {
+		t.Error("Expected the next turn to stay scheduled after a repeated finish")
+	}
+}